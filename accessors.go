@@ -0,0 +1,1022 @@
+package nullable
+
+import (
+	"math/big"
+	"time"
+)
+
+// Accessor is the method set shared by the types of this package, for code
+// reading and writing any of them through T, which is the same type as for
+// Nullable. It is implemented by their pointers, as Set and Clear modify the
+// value. Optional and RawJSON do not implement it: clearing an Optional
+// would have to choose between null and absent, and a RawJSON is nil when null.
+type Accessor[T any] interface {
+	// Ptr returns a pointer to a copy of the value, or nil if it is null.
+	Ptr() *T
+	// ValueOr returns the value, or def if it is null.
+	ValueOr(def T) T
+	// ValueOrZero returns the value, or the zero value of T if it is null.
+	ValueOrZero() T
+	// Get returns the value and whether it is valid.
+	Get() (T, bool)
+	// Set sets the value and marks it as valid.
+	Set(v T)
+	// Clear sets the value to null.
+	Clear()
+	// IsNull reports whether the value is null.
+	IsNull() bool
+}
+
+var (
+	_ Accessor[string]    = (*String)(nil)
+	_ Accessor[int64]     = (*Int64)(nil)
+	_ Accessor[float64]   = (*Float64)(nil)
+	_ Accessor[bool]      = (*Bool)(nil)
+	_ Accessor[time.Time] = (*Time)(nil)
+	_ Accessor[any]       = (*Null[any])(nil)
+
+	_ Accessor[int32]         = (*Int32)(nil)
+	_ Accessor[int16]         = (*Int16)(nil)
+	_ Accessor[int8]          = (*Int8)(nil)
+	_ Accessor[uint64]        = (*Uint64)(nil)
+	_ Accessor[uint32]        = (*Uint32)(nil)
+	_ Accessor[uint16]        = (*Uint16)(nil)
+	_ Accessor[uint8]         = (*Uint8)(nil)
+	_ Accessor[float32]       = (*Float32)(nil)
+	_ Accessor[time.Time]     = (*FormattedTime[DefaultTimeFormat])(nil)
+	_ Accessor[time.Time]     = (*Date)(nil)
+	_ Accessor[time.Duration] = (*TimeOfDay)(nil)
+	_ Accessor[time.Duration] = (*Duration)(nil)
+	_ Accessor[[16]byte]      = (*UUID)(nil)
+	_ Accessor[*big.Rat]      = (*Decimal)(nil)
+	_ Accessor[[]byte]        = (*Bytes)(nil)
+	_ Accessor[[]byte]        = (*BytesAs[HexEncoding])(nil)
+	_ Accessor[any]           = (*JSON[any])(nil)
+)
+
+// decimalRatScale is the number of fractional digits Decimal.Set keeps of
+// a fraction with no finite decimal form, the precision of IEEE 754 decimal128.
+const decimalRatScale = 34
+
+// Ptr returns a pointer to a copy of n.String, or nil if n is null.
+// It is the inverse of MakeString.
+func (n String) Ptr() *string {
+	if !n.Valid {
+		return nil
+	}
+	v := n.String
+	return &v
+}
+
+// ValueOr returns n.String, or def if n is null
+func (n String) ValueOr(def string) string {
+	if !n.Valid {
+		return def
+	}
+	return n.String
+}
+
+// ValueOrZero returns n.String, or its zero value if n is null
+func (n String) ValueOrZero() string {
+	if !n.Valid {
+		return ""
+	}
+	return n.String
+}
+
+// Get returns n.String and whether n is valid, like ValueOrZero
+func (n String) Get() (string, bool) {
+	return n.ValueOrZero(), n.Valid
+}
+
+// Set sets n to the valid value v
+func (n *String) Set(v string) {
+	n.String, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *String) Clear() {
+	*n = String{}
+}
+
+// IsNull reports whether n is null
+func (n String) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Int64, or nil if n is null.
+// It is the inverse of MakeInt64.
+func (n Int64) Ptr() *int64 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Int64
+	return &v
+}
+
+// ValueOr returns n.Int64, or def if n is null
+func (n Int64) ValueOr(def int64) int64 {
+	if !n.Valid {
+		return def
+	}
+	return n.Int64
+}
+
+// ValueOrZero returns n.Int64, or its zero value if n is null
+func (n Int64) ValueOrZero() int64 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Int64
+}
+
+// Get returns n.Int64 and whether n is valid, like ValueOrZero
+func (n Int64) Get() (int64, bool) {
+	return n.ValueOrZero(), n.Valid
+}
+
+// Set sets n to the valid value v
+func (n *Int64) Set(v int64) {
+	n.Int64, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Int64) Clear() {
+	*n = Int64{}
+}
+
+// IsNull reports whether n is null
+func (n Int64) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Float64, or nil if n is null.
+// It is the inverse of MakeFloat64.
+func (n Float64) Ptr() *float64 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Float64
+	return &v
+}
+
+// ValueOr returns n.Float64, or def if n is null
+func (n Float64) ValueOr(def float64) float64 {
+	if !n.Valid {
+		return def
+	}
+	return n.Float64
+}
+
+// ValueOrZero returns n.Float64, or its zero value if n is null
+func (n Float64) ValueOrZero() float64 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Float64
+}
+
+// Get returns n.Float64 and whether n is valid, like ValueOrZero
+func (n Float64) Get() (float64, bool) {
+	return n.ValueOrZero(), n.Valid
+}
+
+// Set sets n to the valid value v
+func (n *Float64) Set(v float64) {
+	n.Float64, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Float64) Clear() {
+	*n = Float64{}
+}
+
+// IsNull reports whether n is null
+func (n Float64) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Bool, or nil if n is null.
+// It is the inverse of MakeBool.
+func (n Bool) Ptr() *bool {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Bool
+	return &v
+}
+
+// ValueOr returns n.Bool, or def if n is null
+func (n Bool) ValueOr(def bool) bool {
+	if !n.Valid {
+		return def
+	}
+	return n.Bool
+}
+
+// ValueOrZero returns n.Bool, or its zero value if n is null
+func (n Bool) ValueOrZero() bool {
+	if !n.Valid {
+		return false
+	}
+	return n.Bool
+}
+
+// Get returns n.Bool and whether n is valid, like ValueOrZero
+func (n Bool) Get() (bool, bool) {
+	return n.ValueOrZero(), n.Valid
+}
+
+// Set sets n to the valid value v
+func (n *Bool) Set(v bool) {
+	n.Bool, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Bool) Clear() {
+	*n = Bool{}
+}
+
+// IsNull reports whether n is null
+func (n Bool) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Time, or nil if n is null
+func (n Time) Ptr() *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Time
+	return &v
+}
+
+// ValueOr returns n.Time, or def if n is null
+func (n Time) ValueOr(def time.Time) time.Time {
+	if !n.Valid {
+		return def
+	}
+	return n.Time
+}
+
+// ValueOrZero returns n.Time, or its zero value if n is null
+func (n Time) ValueOrZero() time.Time {
+	if !n.Valid {
+		return time.Time{}
+	}
+	return n.Time
+}
+
+// Get returns n.Time and whether n is valid, like ValueOrZero
+func (n Time) Get() (time.Time, bool) {
+	return n.ValueOrZero(), n.Valid
+}
+
+// Set sets n to the valid value v
+func (n *Time) Set(v time.Time) {
+	n.Time, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Time) Clear() {
+	*n = Time{}
+}
+
+// IsNull reports whether n is null
+func (n Time) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.V, or nil if n is null
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+// ValueOr returns n.V, or def if n is null
+func (n Null[T]) ValueOr(def T) T {
+	if !n.Valid {
+		return def
+	}
+	return n.V
+}
+
+// ValueOrZero returns n.V, or its zero value if n is null
+func (n Null[T]) ValueOrZero() T {
+	if !n.Valid {
+		var zero T
+		return zero
+	}
+	return n.V
+}
+
+// Get returns n.V and whether n is valid, like ValueOrZero
+func (n Null[T]) Get() (T, bool) {
+	return n.ValueOrZero(), n.Valid
+}
+
+// Set sets n to the valid value v
+func (n *Null[T]) Set(v T) {
+	n.V, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Null[T]) Clear() {
+	*n = Null[T]{}
+}
+
+// IsNull reports whether n is null
+func (n Null[T]) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Int32, or nil if n is null.
+// It is the inverse of MakeInt32.
+func (n Int32) Ptr() *int32 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Int32
+	return &v
+}
+
+// ValueOr returns n.Int32, or def if n is null
+func (n Int32) ValueOr(def int32) int32 {
+	if !n.Valid {
+		return def
+	}
+	return n.Int32
+}
+
+// ValueOrZero returns n.Int32, or its zero value if n is null
+func (n Int32) ValueOrZero() int32 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Int32
+}
+
+// Set sets n to the valid value v
+func (n *Int32) Set(v int32) {
+	n.Int32, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Int32) Clear() {
+	*n = Int32{}
+}
+
+// IsNull reports whether n is null
+func (n Int32) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Int16, or nil if n is null.
+// It is the inverse of MakeInt16.
+func (n Int16) Ptr() *int16 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Int16
+	return &v
+}
+
+// ValueOr returns n.Int16, or def if n is null
+func (n Int16) ValueOr(def int16) int16 {
+	if !n.Valid {
+		return def
+	}
+	return n.Int16
+}
+
+// ValueOrZero returns n.Int16, or its zero value if n is null
+func (n Int16) ValueOrZero() int16 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Int16
+}
+
+// Set sets n to the valid value v
+func (n *Int16) Set(v int16) {
+	n.Int16, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Int16) Clear() {
+	*n = Int16{}
+}
+
+// IsNull reports whether n is null
+func (n Int16) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Int8, or nil if n is null.
+// It is the inverse of MakeInt8.
+func (n Int8) Ptr() *int8 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Int8
+	return &v
+}
+
+// ValueOr returns n.Int8, or def if n is null
+func (n Int8) ValueOr(def int8) int8 {
+	if !n.Valid {
+		return def
+	}
+	return n.Int8
+}
+
+// ValueOrZero returns n.Int8, or its zero value if n is null
+func (n Int8) ValueOrZero() int8 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Int8
+}
+
+// Set sets n to the valid value v
+func (n *Int8) Set(v int8) {
+	n.Int8, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Int8) Clear() {
+	*n = Int8{}
+}
+
+// IsNull reports whether n is null
+func (n Int8) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Uint64, or nil if n is null.
+// It is the inverse of MakeUint64.
+func (n Uint64) Ptr() *uint64 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Uint64
+	return &v
+}
+
+// ValueOr returns n.Uint64, or def if n is null
+func (n Uint64) ValueOr(def uint64) uint64 {
+	if !n.Valid {
+		return def
+	}
+	return n.Uint64
+}
+
+// ValueOrZero returns n.Uint64, or its zero value if n is null
+func (n Uint64) ValueOrZero() uint64 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Uint64
+}
+
+// Set sets n to the valid value v
+func (n *Uint64) Set(v uint64) {
+	n.Uint64, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Uint64) Clear() {
+	*n = Uint64{}
+}
+
+// IsNull reports whether n is null
+func (n Uint64) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Uint32, or nil if n is null.
+// It is the inverse of MakeUint32.
+func (n Uint32) Ptr() *uint32 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Uint32
+	return &v
+}
+
+// ValueOr returns n.Uint32, or def if n is null
+func (n Uint32) ValueOr(def uint32) uint32 {
+	if !n.Valid {
+		return def
+	}
+	return n.Uint32
+}
+
+// ValueOrZero returns n.Uint32, or its zero value if n is null
+func (n Uint32) ValueOrZero() uint32 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Uint32
+}
+
+// Set sets n to the valid value v
+func (n *Uint32) Set(v uint32) {
+	n.Uint32, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Uint32) Clear() {
+	*n = Uint32{}
+}
+
+// IsNull reports whether n is null
+func (n Uint32) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Uint16, or nil if n is null.
+// It is the inverse of MakeUint16.
+func (n Uint16) Ptr() *uint16 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Uint16
+	return &v
+}
+
+// ValueOr returns n.Uint16, or def if n is null
+func (n Uint16) ValueOr(def uint16) uint16 {
+	if !n.Valid {
+		return def
+	}
+	return n.Uint16
+}
+
+// ValueOrZero returns n.Uint16, or its zero value if n is null
+func (n Uint16) ValueOrZero() uint16 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Uint16
+}
+
+// Set sets n to the valid value v
+func (n *Uint16) Set(v uint16) {
+	n.Uint16, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Uint16) Clear() {
+	*n = Uint16{}
+}
+
+// IsNull reports whether n is null
+func (n Uint16) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Uint8, or nil if n is null.
+// It is the inverse of MakeUint8.
+func (n Uint8) Ptr() *uint8 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Uint8
+	return &v
+}
+
+// ValueOr returns n.Uint8, or def if n is null
+func (n Uint8) ValueOr(def uint8) uint8 {
+	if !n.Valid {
+		return def
+	}
+	return n.Uint8
+}
+
+// ValueOrZero returns n.Uint8, or its zero value if n is null
+func (n Uint8) ValueOrZero() uint8 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Uint8
+}
+
+// Set sets n to the valid value v
+func (n *Uint8) Set(v uint8) {
+	n.Uint8, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Uint8) Clear() {
+	*n = Uint8{}
+}
+
+// IsNull reports whether n is null
+func (n Uint8) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Float32, or nil if n is null.
+// It is the inverse of MakeFloat32.
+func (n Float32) Ptr() *float32 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Float32
+	return &v
+}
+
+// ValueOr returns n.Float32, or def if n is null
+func (n Float32) ValueOr(def float32) float32 {
+	if !n.Valid {
+		return def
+	}
+	return n.Float32
+}
+
+// ValueOrZero returns n.Float32, or its zero value if n is null
+func (n Float32) ValueOrZero() float32 {
+	if !n.Valid {
+		return 0
+	}
+	return n.Float32
+}
+
+// Set sets n to the valid value v
+func (n *Float32) Set(v float32) {
+	n.Float32, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Float32) Clear() {
+	*n = Float32{}
+}
+
+// IsNull reports whether n is null
+func (n Float32) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Time, or nil if n is null
+func (n FormattedTime[F]) Ptr() *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Time
+	return &v
+}
+
+// ValueOr returns n.Time, or def if n is null
+func (n FormattedTime[F]) ValueOr(def time.Time) time.Time {
+	if !n.Valid {
+		return def
+	}
+	return n.Time
+}
+
+// ValueOrZero returns n.Time, or its zero value if n is null
+func (n FormattedTime[F]) ValueOrZero() time.Time {
+	if !n.Valid {
+		return time.Time{}
+	}
+	return n.Time
+}
+
+// Set sets n to the valid value v
+func (n *FormattedTime[F]) Set(v time.Time) {
+	n.Time, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *FormattedTime[F]) Clear() {
+	*n = FormattedTime[F]{}
+}
+
+// IsNull reports whether n is null
+func (n FormattedTime[F]) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Duration, or nil if n is null.
+// It is the inverse of MakeDuration.
+func (n Duration) Ptr() *time.Duration {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Duration
+	return &v
+}
+
+// ValueOr returns n.Duration, or def if n is null
+func (n Duration) ValueOr(def time.Duration) time.Duration {
+	if !n.Valid {
+		return def
+	}
+	return n.Duration
+}
+
+// ValueOrZero returns n.Duration, or its zero value if n is null
+func (n Duration) ValueOrZero() time.Duration {
+	if !n.Valid {
+		return 0
+	}
+	return n.Duration
+}
+
+// Set sets n to the valid value v
+func (n *Duration) Set(v time.Duration) {
+	n.Duration, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Duration) Clear() {
+	*n = Duration{}
+}
+
+// IsNull reports whether n is null
+func (n Duration) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.UUID, or nil if n is null
+func (n UUID) Ptr() *[16]byte {
+	if !n.Valid {
+		return nil
+	}
+	v := n.UUID
+	return &v
+}
+
+// ValueOr returns n.UUID, or def if n is null
+func (n UUID) ValueOr(def [16]byte) [16]byte {
+	if !n.Valid {
+		return def
+	}
+	return n.UUID
+}
+
+// ValueOrZero returns n.UUID, or its zero value if n is null
+func (n UUID) ValueOrZero() [16]byte {
+	if !n.Valid {
+		return [16]byte{}
+	}
+	return n.UUID
+}
+
+// Set sets n to the valid value v
+func (n *UUID) Set(v [16]byte) {
+	n.UUID, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *UUID) Clear() {
+	*n = UUID{}
+}
+
+// IsNull reports whether n is null
+func (n UUID) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Bytes, or nil if n is null
+func (n Bytes) Ptr() *[]byte {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Bytes
+	return &v
+}
+
+// ValueOr returns n.Bytes, or def if n is null
+func (n Bytes) ValueOr(def []byte) []byte {
+	if !n.Valid {
+		return def
+	}
+	return n.Bytes
+}
+
+// ValueOrZero returns n.Bytes, or its zero value if n is null
+func (n Bytes) ValueOrZero() []byte {
+	if !n.Valid {
+		return nil
+	}
+	return n.Bytes
+}
+
+// Set sets n to the valid value v
+func (n *Bytes) Set(v []byte) {
+	n.Bytes, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *Bytes) Clear() {
+	*n = Bytes{}
+}
+
+// IsNull reports whether n is null
+func (n Bytes) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.Bytes, or nil if n is null
+func (n BytesAs[E]) Ptr() *[]byte {
+	return Bytes(n).Ptr()
+}
+
+// ValueOr returns n.Bytes, or def if n is null
+func (n BytesAs[E]) ValueOr(def []byte) []byte {
+	return Bytes(n).ValueOr(def)
+}
+
+// ValueOrZero returns n.Bytes, or its zero value if n is null
+func (n BytesAs[E]) ValueOrZero() []byte {
+	return Bytes(n).ValueOrZero()
+}
+
+// Set sets n to the valid value v
+func (n *BytesAs[E]) Set(v []byte) {
+	n.Bytes, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *BytesAs[E]) Clear() {
+	*n = BytesAs[E]{}
+}
+
+// IsNull reports whether n is null
+func (n BytesAs[E]) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to a copy of n.V, or nil if n is null
+func (n JSON[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+// ValueOr returns n.V, or def if n is null
+func (n JSON[T]) ValueOr(def T) T {
+	if !n.Valid {
+		return def
+	}
+	return n.V
+}
+
+// ValueOrZero returns n.V, or its zero value if n is null
+func (n JSON[T]) ValueOrZero() T {
+	if !n.Valid {
+		var zero T
+		return zero
+	}
+	return n.V
+}
+
+// Set sets n to the valid value v
+func (n *JSON[T]) Set(v T) {
+	n.V, n.Valid = v, true
+}
+
+// Clear sets n to null
+func (n *JSON[T]) Clear() {
+	*n = JSON[T]{}
+}
+
+// IsNull reports whether n is null
+func (n JSON[T]) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to the time at midnight UTC on n, or nil if n is null
+func (n Date) Ptr() *time.Time {
+	v, ok := n.Get()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// ValueOr returns the time at midnight UTC on n, or def if n is null
+func (n Date) ValueOr(def time.Time) time.Time {
+	if v, ok := n.Get(); ok {
+		return v
+	}
+	return def
+}
+
+// ValueOrZero returns the time at midnight UTC on n, or the zero time if n is null
+func (n Date) ValueOrZero() time.Time {
+	v, _ := n.Get()
+	return v
+}
+
+// Set sets n to the date of v in its location, like MakeDate
+func (n *Date) Set(v time.Time) {
+	n.set(v)
+}
+
+// Clear sets n to null
+func (n *Date) Clear() {
+	*n = Date{}
+}
+
+// IsNull reports whether n is null
+func (n Date) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns a pointer to the duration since midnight of n, or nil if n is null
+func (n TimeOfDay) Ptr() *time.Duration {
+	v, ok := n.Get()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// ValueOr returns the duration since midnight of n, or def if n is null
+func (n TimeOfDay) ValueOr(def time.Duration) time.Duration {
+	if v, ok := n.Get(); ok {
+		return v
+	}
+	return def
+}
+
+// ValueOrZero returns the duration since midnight of n, or 0 if n is null
+func (n TimeOfDay) ValueOrZero() time.Duration {
+	v, _ := n.Get()
+	return v
+}
+
+// Set sets n to the time of day v after midnight.
+// Like a clock, v wraps around every 24 hours, so that -time.Hour is 23:00.
+func (n *TimeOfDay) Set(v time.Duration) {
+	n.set(time.Time{}.Add(v))
+}
+
+// Clear sets n to null
+func (n *TimeOfDay) Clear() {
+	*n = TimeOfDay{}
+}
+
+// IsNull reports whether n is null
+func (n TimeOfDay) IsNull() bool {
+	return !n.Valid
+}
+
+// Ptr returns n as a new exact big.Rat, or nil if n is null.
+// As Get, it never returns a nil pointer for a valid value.
+func (n Decimal) Ptr() **big.Rat {
+	v, ok := n.Get()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// ValueOr returns n as a new exact big.Rat, or def if n is null
+func (n Decimal) ValueOr(def *big.Rat) *big.Rat {
+	if v, ok := n.Get(); ok {
+		return v
+	}
+	return def
+}
+
+// ValueOrZero returns n as a new exact big.Rat, or nil if n is null
+func (n Decimal) ValueOrZero() *big.Rat {
+	v, _ := n.Get()
+	return v
+}
+
+// Set sets n to v, or to null if v is nil.
+// The scale is the smallest that holds v exactly. A fraction with no finite
+// decimal form, such as 1/3, is rounded half away from zero to
+// decimalRatScale fractional digits.
+func (n *Decimal) Set(v *big.Rat) {
+	if v == nil {
+		*n = Decimal{}
+		return
+	}
+	scale, exact := v.FloatPrec()
+	if !exact {
+		scale = decimalRatScale
+	}
+	r := new(big.Rat).Mul(v, new(big.Rat).SetInt(pow10(int32(scale))))
+	*n = Decimal{coef: roundRat(r), scale: int32(scale), Valid: true}
+}
+
+// Clear sets n to null
+func (n *Decimal) Clear() {
+	*n = Decimal{}
+}
+
+// IsNull reports whether n is null
+func (n Decimal) IsNull() bool {
+	return !n.Valid
+}
@@ -0,0 +1,19 @@
+//go:build go1.24
+
+package nullable
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestAppenders(t *testing.T) {
+	for _, tt := range encoderValues() {
+		if _, ok := tt.value.(encoding.BinaryAppender); !ok {
+			t.Errorf("%s does not implement encoding.BinaryAppender", tt.name)
+		}
+		if _, ok := tt.value.(encoding.TextAppender); !ok {
+			t.Errorf("%s does not implement encoding.TextAppender", tt.name)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package nullable
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// ErrOverflow is returned by Int64 and Float64 arithmetic and Sum when the
+// result is out of range, where PostgreSQL reports "bigint out of range"
+// or "value out of range: overflow".
+var ErrOverflow = errors.New("nullable: value out of range")
+
+// addInt64 returns a + b, reporting false if it overflows
+func addInt64(a, b int64) (int64, bool) {
+	s := a + b
+	return s, (s > a) == (b > 0)
+}
+
+// subInt64 returns a - b, reporting false if it overflows
+func subInt64(a, b int64) (int64, bool) {
+	d := a - b
+	return d, (d < a) == (b > 0)
+}
+
+// mulInt64 returns a * b, reporting false if it overflows
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	p := a * b
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return p, false
+	}
+	return p, p/b == a
+}
+
+// Sum returns the sum of the valid values, ignoring nulls.
+// Like SUM in SQL, it is null if no value is valid.
+// It returns ErrOverflow if the sum is out of range, as Add does.
+func Sum[N Int64 | Float64](values []N) (N, error) {
+	var sum N
+	switch values := any(values).(type) {
+	case []Int64:
+		s := any(&sum).(*Int64)
+		for _, v := range values {
+			if !v.Valid {
+				continue
+			}
+			var ok bool
+			if s.Int64, ok = addInt64(s.Int64, v.Int64); !ok {
+				return *new(N), ErrOverflow
+			}
+			s.Valid = true
+		}
+	case []Float64:
+		s := any(&sum).(*Float64)
+		for _, v := range values {
+			if !v.Valid {
+				continue
+			}
+			var err error
+			if *s, err = checkedFloat64(s.Float64+v.Float64, s.Float64, v.Float64); err != nil {
+				return *new(N), err
+			}
+		}
+	}
+	return sum, nil
+}
+
+// Avg returns the mean of the valid values, ignoring nulls.
+// Like AVG in SQL, it is null if no value is valid.
+// Int64 values are summed exactly, so that the mean is only rounded once.
+func Avg[N Int64 | Float64](values []N) Float64 {
+	var avg float64
+	var count int64
+	switch values := any(values).(type) {
+	case []Int64:
+		sum, v := new(big.Int), new(big.Int)
+		for _, n := range values {
+			if n.Valid {
+				sum.Add(sum, v.SetInt64(n.Int64))
+				count++
+			}
+		}
+		if count > 0 {
+			avg, _ = new(big.Rat).SetFrac(sum, big.NewInt(count)).Float64()
+		}
+	case []Float64:
+		var sum float64
+		for _, n := range values {
+			if n.Valid {
+				sum += n.Float64
+				count++
+			}
+		}
+		if count > 0 {
+			avg = sum / float64(count)
+		}
+	}
+	if count == 0 {
+		return Float64{}
+	}
+	return Float64{Float64: avg, Valid: true}
+}
+
+// Min returns the smallest of the valid values, ignoring nulls.
+// Like MIN in SQL, it is null if no value is valid.
+func Min[N Int64 | Float64](values []N) N {
+	return extremum(values, -1)
+}
+
+// Max returns the largest of the valid values, ignoring nulls.
+// Like MAX in SQL, it is null if no value is valid.
+func Max[N Int64 | Float64](values []N) N {
+	return extremum(values, +1)
+}
+
+// extremum returns the valid value v for which v.Cmp(other) == sign
+// against every other valid value, or null if there is none.
+func extremum[N Int64 | Float64](values []N, sign int) N {
+	var m N
+	for _, v := range values {
+		switch v := any(v).(type) {
+		case Int64:
+			m := any(&m).(*Int64)
+			if c, ok := v.Cmp(*m); v.Valid && (!m.Valid || (ok && c == sign)) {
+				*m = v
+			}
+		case Float64:
+			m := any(&m).(*Float64)
+			if c, ok := v.Cmp(*m); v.Valid && (!m.Valid || (ok && c == sign)) {
+				*m = v
+			}
+		}
+	}
+	return m
+}
+
+// Count returns the number of valid values, ignoring nulls, like COUNT in SQL.
+// It accepts a slice of any type of this package.
+func Count[N interface{ IsValid() bool }](values []N) int {
+	count := 0
+	for _, v := range values {
+		if v.IsValid() {
+			count++
+		}
+	}
+	return count
+}
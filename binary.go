@@ -0,0 +1,962 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Every type in this package implements encoding.BinaryMarshaler,
+// encoding.BinaryUnmarshaler and encoding.BinaryAppender using a compact
+// binary form: a single byte, 0 for null and 1 for valid, followed for
+// valid values by their payload:
+//
+//   - signed integers and Duration as varints, unsigned integers as uvarints
+//   - floats as their IEEE 754 bits, big endian
+//   - String, Bytes and RawJSON as their raw bytes
+//   - Time as a varint of its Unix seconds, a uvarint of its nanoseconds and
+//     a varint of its zone offset in seconds, followed by the name of its location
+//   - Date as a varint year followed by the month and day bytes
+//   - TimeOfDay as a uvarint number of nanoseconds since midnight
+//   - UUID as its 16 bytes
+//   - Decimal as a varint scale followed by its coefficient, as big.Int.GobEncode does
+//   - JSON as its JSON document
+//
+// Null and Optional encode V as described above when T is one of those basic
+// kinds or implements encoding.BinaryMarshaler, and using gob otherwise.
+//
+// Encoders that prefer encoding.BinaryMarshaler, such as msgpack for types
+// not registered by package nullmsgpack, write this form as opaque bytes.
+//
+// AppendBinary does not allocate, except for JSON, and for Null and Optional
+// when T is neither a predeclared string, integer or float type nor
+// implements encoding.BinaryAppender.
+
+// The validity byte starting every binary encoding.
+const (
+	binaryNull  = 0
+	binaryValid = 1
+)
+
+var errBinaryLength = errors.New("nullable: invalid binary length")
+
+// readBinary returns the payload of the binary encoding b,
+// and whether it holds a valid value.
+func readBinary(b []byte) (payload []byte, valid bool, err error) {
+	switch {
+	case len(b) == 1 && b[0] == binaryNull:
+		return nil, false, nil
+	case len(b) >= 1 && b[0] == binaryValid:
+		return b[1:], true, nil
+	case len(b) == 0:
+		return nil, false, errBinaryLength
+	}
+	return nil, false, fmt.Errorf("nullable: invalid binary header %#x", b[0])
+}
+
+// readVarint decodes a varint filling payload, which must fit in bits.
+func readVarint(payload []byte, bits int) (int64, error) {
+	v, n := binary.Varint(payload)
+	if n <= 0 || n != len(payload) {
+		return 0, errBinaryLength
+	}
+	if bits < 64 && (v < -1<<(bits-1) || v >= 1<<(bits-1)) {
+		return 0, fmt.Errorf("nullable: %d overflows int%d", v, bits)
+	}
+	return v, nil
+}
+
+// readUvarint decodes a uvarint filling payload, which must fit in bits.
+func readUvarint(payload []byte, bits int) (uint64, error) {
+	v, n := binary.Uvarint(payload)
+	if n <= 0 || n != len(payload) {
+		return 0, errBinaryLength
+	}
+	if bits < 64 && v >= 1<<bits {
+		return 0, fmt.Errorf("nullable: %d overflows uint%d", v, bits)
+	}
+	return v, nil
+}
+
+// appendTimeBinary appends the Unix seconds, nanoseconds and zone offset of t,
+// followed by the name of its location unless it is UTC.
+// The monotonic clock reading is not kept.
+func appendTimeBinary(b []byte, t time.Time) []byte {
+	_, offset := t.Zone()
+	b = binary.AppendVarint(b, t.Unix())
+	b = binary.AppendUvarint(b, uint64(t.Nanosecond()))
+	b = binary.AppendVarint(b, int64(offset))
+	if loc := t.Location(); loc != time.UTC {
+		b = append(b, loc.String()...)
+	}
+	return b
+}
+
+// readTimeBinary decodes a time appended by appendTimeBinary.
+// The location is restored if it can be loaded and agrees with the encoded offset,
+// and is otherwise replaced by a fixed zone of the same name and offset.
+func readTimeBinary(payload []byte) (time.Time, error) {
+	sec, n := binary.Varint(payload)
+	if n <= 0 {
+		return time.Time{}, errBinaryLength
+	}
+	payload = payload[n:]
+	nsec, n := binary.Uvarint(payload)
+	if n <= 0 || nsec >= uint64(time.Second) {
+		return time.Time{}, errBinaryLength
+	}
+	payload = payload[n:]
+	offset, n := binary.Varint(payload)
+	if n <= 0 || offset < math.MinInt32 || offset > math.MaxInt32 {
+		return time.Time{}, errBinaryLength
+	}
+
+	t := time.Unix(sec, int64(nsec))
+	name := string(payload[n:])
+	if name == "" && offset == 0 {
+		return t.UTC(), nil
+	}
+	if loc, ok := loadLocation(name); ok {
+		if _, locOffset := t.In(loc).Zone(); int64(locOffset) == offset {
+			return t.In(loc), nil
+		}
+	}
+	return t.In(time.FixedZone(name, int(offset))), nil
+}
+
+// locations caches the locations loaded by loadLocation, by name.
+var locations sync.Map // map[string]*time.Location
+
+// loadLocation is time.LoadLocation, without reading the time zone
+// database again for names it has already loaded.
+func loadLocation(name string) (*time.Location, bool) {
+	if name == "Local" {
+		return time.Local, true
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), true
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, false
+	}
+	locations.Store(name, loc)
+	return loc, true
+}
+
+// appendBigInt appends x in the form of big.Int.GobEncode,
+// which big.Int.GobDecode decodes.
+func appendBigInt(b []byte, x *big.Int) []byte {
+	const version = 1
+	header := byte(version << 1)
+	if x == nil {
+		return append(b, header)
+	}
+	if x.Sign() < 0 {
+		header |= 1
+	}
+	b = append(b, header)
+	size := (x.BitLen() + 7) / 8
+	b = append(b, make([]byte, size)...)
+	x.FillBytes(b[len(b)-size:])
+	return b
+}
+
+// appendBinaryValue appends the payload of v, using its encoding.BinaryAppender
+// or encoding.BinaryMarshaler implementation, the binary form of basic kinds,
+// or gob otherwise.
+func appendBinaryValue[T any](b []byte, v T) ([]byte, error) {
+	switch v := any(v).(type) {
+	case string:
+		return append(b, v...), nil
+	case []byte:
+		return append(b, v...), nil
+	case int:
+		return binary.AppendVarint(b, int64(v)), nil
+	case int64:
+		return binary.AppendVarint(b, v), nil
+	case int32:
+		return binary.AppendVarint(b, int64(v)), nil
+	case uint64:
+		return binary.AppendUvarint(b, v), nil
+	case uint32:
+		return binary.AppendUvarint(b, uint64(v)), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(v)), nil
+	}
+	return appendBinaryAny(b, v)
+}
+
+// appendBinaryAny is appendBinaryValue for any other type.
+func appendBinaryAny(b []byte, v any) ([]byte, error) {
+	switch m := v.(type) {
+	case binaryAppender:
+		return m.AppendBinary(b)
+	case encoding.BinaryMarshaler:
+		data, err := m.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return append(b, data...), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return append(b, rv.String()...), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(b, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(b, rv.Uint()), nil
+	case reflect.Float32:
+		return binary.BigEndian.AppendUint32(b, math.Float32bits(float32(rv.Float()))), nil
+	case reflect.Float64:
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(rv.Float())), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return append(b, rv.Bytes()...), nil
+		}
+	}
+
+	buf := bytes.NewBuffer(b)
+	if err := gob.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readBinaryValue decodes a payload appended by appendBinaryValue
+// into the value ptr points to.
+func readBinaryValue(ptr any, payload []byte) error {
+	if u, ok := ptr.(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(payload)
+	}
+
+	rv := reflect.ValueOf(ptr).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(string(payload))
+		return nil
+	case reflect.Bool:
+		if len(payload) != 1 || payload[0] > 1 {
+			return errBinaryLength
+		}
+		rv.SetBool(payload[0] == 1)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := readVarint(payload, rv.Type().Bits())
+		rv.SetInt(v)
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := readUvarint(payload, rv.Type().Bits())
+		rv.SetUint(v)
+		return err
+	case reflect.Float32:
+		if len(payload) != 4 {
+			return errBinaryLength
+		}
+		rv.SetFloat(float64(math.Float32frombits(binary.BigEndian.Uint32(payload))))
+		return nil
+	case reflect.Float64:
+		if len(payload) != 8 {
+			return errBinaryLength
+		}
+		rv.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(payload)))
+		return nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes(append([]byte{}, payload...))
+			return nil
+		}
+	}
+	return gob.NewDecoder(bytes.NewReader(payload)).Decode(ptr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n String) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n String) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return append(append(b, binaryValid), n.String...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *String) UnmarshalBinary(b []byte) error {
+	n.String, n.Valid = "", false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	n.String, n.Valid = string(payload), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Int64) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Int64) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendVarint(append(b, binaryValid), n.Int64), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Int64) UnmarshalBinary(b []byte) error {
+	n.Int64, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readVarint(payload, 64)
+	if err != nil {
+		return err
+	}
+	n.Int64, n.Valid = v, true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Int32) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Int32) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendVarint(append(b, binaryValid), int64(n.Int32)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Int32) UnmarshalBinary(b []byte) error {
+	n.Int32, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readVarint(payload, 32)
+	if err != nil {
+		return err
+	}
+	n.Int32, n.Valid = int32(v), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Int16) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Int16) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendVarint(append(b, binaryValid), int64(n.Int16)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Int16) UnmarshalBinary(b []byte) error {
+	n.Int16, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readVarint(payload, 16)
+	if err != nil {
+		return err
+	}
+	n.Int16, n.Valid = int16(v), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Int8) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Int8) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendVarint(append(b, binaryValid), int64(n.Int8)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Int8) UnmarshalBinary(b []byte) error {
+	n.Int8, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readVarint(payload, 8)
+	if err != nil {
+		return err
+	}
+	n.Int8, n.Valid = int8(v), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Uint64) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Uint64) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendUvarint(append(b, binaryValid), n.Uint64), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Uint64) UnmarshalBinary(b []byte) error {
+	n.Uint64, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readUvarint(payload, 64)
+	if err != nil {
+		return err
+	}
+	n.Uint64, n.Valid = v, true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Uint32) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Uint32) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendUvarint(append(b, binaryValid), uint64(n.Uint32)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Uint32) UnmarshalBinary(b []byte) error {
+	n.Uint32, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readUvarint(payload, 32)
+	if err != nil {
+		return err
+	}
+	n.Uint32, n.Valid = uint32(v), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Uint16) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Uint16) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendUvarint(append(b, binaryValid), uint64(n.Uint16)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Uint16) UnmarshalBinary(b []byte) error {
+	n.Uint16, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readUvarint(payload, 16)
+	if err != nil {
+		return err
+	}
+	n.Uint16, n.Valid = uint16(v), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Uint8) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Uint8) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendUvarint(append(b, binaryValid), uint64(n.Uint8)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Uint8) UnmarshalBinary(b []byte) error {
+	n.Uint8, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readUvarint(payload, 8)
+	if err != nil {
+		return err
+	}
+	n.Uint8, n.Valid = uint8(v), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Float64) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Float64) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.BigEndian.AppendUint64(append(b, binaryValid), math.Float64bits(n.Float64)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Float64) UnmarshalBinary(b []byte) error {
+	n.Float64, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	if len(payload) != 8 {
+		return errBinaryLength
+	}
+	n.Float64, n.Valid = math.Float64frombits(binary.BigEndian.Uint64(payload)), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Float32) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Float32) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.BigEndian.AppendUint32(append(b, binaryValid), math.Float32bits(n.Float32)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Float32) UnmarshalBinary(b []byte) error {
+	n.Float32, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	if len(payload) != 4 {
+		return errBinaryLength
+	}
+	n.Float32, n.Valid = math.Float32frombits(binary.BigEndian.Uint32(payload)), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Bool) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Bool) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	if n.Bool {
+		return append(b, binaryValid, 1), nil
+	}
+	return append(b, binaryValid, 0), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Bool) UnmarshalBinary(b []byte) error {
+	n.Bool, n.Valid = false, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	if len(payload) != 1 || payload[0] > 1 {
+		return errBinaryLength
+	}
+	n.Bool, n.Valid = payload[0] == 1, true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The location of the time is kept, but not its monotonic clock reading.
+func (n Time) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender, like MarshalBinary
+func (n Time) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return appendTimeBinary(append(b, binaryValid), n.Time), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Time) UnmarshalBinary(b []byte) error {
+	n.Time, n.Valid = time.Time{}, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	t, err := readTimeBinary(payload)
+	if err != nil {
+		return err
+	}
+	n.Time, n.Valid = t, true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, like Time.MarshalBinary
+func (n FormattedTime[F]) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender, like Time.AppendBinary
+func (n FormattedTime[F]) AppendBinary(b []byte) ([]byte, error) {
+	return Time{Time: n.Time, Valid: n.Valid}.AppendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, like Time.UnmarshalBinary
+func (n *FormattedTime[F]) UnmarshalBinary(b []byte) error {
+	var t Time
+	err := t.UnmarshalBinary(b)
+	n.Time, n.Valid = t.Time, t.Valid
+	return err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Date) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Date) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	b = binary.AppendVarint(append(b, binaryValid), int64(n.Year))
+	return append(b, byte(n.Month), byte(n.Day)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Date) UnmarshalBinary(b []byte) error {
+	*n = Date{}
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	if len(payload) < 3 {
+		return errBinaryLength
+	}
+	year, err := readVarint(payload[:len(payload)-2], 64)
+	if err != nil {
+		return err
+	}
+	month, day := payload[len(payload)-2], payload[len(payload)-1]
+	*n = Date{Year: int(year), Month: time.Month(month), Day: int(day), Valid: true}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n TimeOfDay) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n TimeOfDay) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendUvarint(append(b, binaryValid), uint64(n.sinceMidnight())), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *TimeOfDay) UnmarshalBinary(b []byte) error {
+	*n = TimeOfDay{}
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readUvarint(payload, 64)
+	if err != nil {
+		return err
+	}
+	if v >= uint64(24*time.Hour) {
+		return fmt.Errorf("nullable: %d nanoseconds is out of range for TimeOfDay", v)
+	}
+	n.set(time.Time{}.Add(time.Duration(v)))
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Duration) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Duration) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return binary.AppendVarint(append(b, binaryValid), int64(n.Duration)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Duration) UnmarshalBinary(b []byte) error {
+	n.Duration, n.Valid = 0, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	v, err := readVarint(payload, 64)
+	if err != nil {
+		return err
+	}
+	n.Duration, n.Valid = time.Duration(v), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n UUID) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n UUID) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return append(append(b, binaryValid), n.UUID[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *UUID) UnmarshalBinary(b []byte) error {
+	n.UUID, n.Valid = [16]byte{}, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	if len(payload) != len(n.UUID) {
+		return errBinaryLength
+	}
+	copy(n.UUID[:], payload)
+	n.Valid = true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Decimal) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Decimal) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	b = binary.AppendVarint(append(b, binaryValid), int64(n.scale))
+	return appendBigInt(b, n.coef), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Decimal) UnmarshalBinary(b []byte) error {
+	*n = Decimal{}
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	scale, size := binary.Varint(payload)
+	if size <= 0 || scale < -maxDecimalScale || scale > maxDecimalScale {
+		return errBinaryLength
+	}
+	coef := new(big.Int)
+	if err := coef.GobDecode(payload[size:]); err != nil {
+		return err
+	}
+	*n = Decimal{coef: coef, scale: int32(scale), Valid: true}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Bytes) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Bytes) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return append(append(b, binaryValid), n.Bytes...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Bytes) UnmarshalBinary(b []byte) error {
+	n.Bytes, n.Valid = nil, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	n.Bytes, n.Valid = append([]byte{}, payload...), true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, like Bytes.MarshalBinary
+func (n BytesAs[E]) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender, like Bytes.AppendBinary
+func (n BytesAs[E]) AppendBinary(b []byte) ([]byte, error) {
+	return Bytes(n).AppendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, like Bytes.UnmarshalBinary
+func (n *BytesAs[E]) UnmarshalBinary(b []byte) error {
+	return (*Bytes)(n).UnmarshalBinary(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n RawJSON) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n RawJSON) AppendBinary(b []byte) ([]byte, error) {
+	if len(n) == 0 {
+		return append(b, binaryNull), nil
+	}
+	return append(append(b, binaryValid), n...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It returns an error if the payload is not valid JSON.
+func (n *RawJSON) UnmarshalBinary(b []byte) error {
+	*n = nil
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	return n.Scan(payload)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (n Null[T]) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (n Null[T]) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	return appendBinaryValue(append(b, binaryValid), n.V)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *Null[T]) UnmarshalBinary(b []byte) error {
+	var zero T
+	n.V, n.Valid = zero, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	if err := readBinaryValue(&n.V, payload); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, like Null.MarshalBinary
+func (o Optional[T]) MarshalBinary() ([]byte, error) {
+	return o.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender, like Null.AppendBinary
+func (o Optional[T]) AppendBinary(b []byte) ([]byte, error) {
+	return Null[T]{V: o.V, Valid: o.Valid}.AppendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, like Null.UnmarshalBinary
+func (o *Optional[T]) UnmarshalBinary(b []byte) error {
+	o.Present = true
+	var n Null[T]
+	err := n.UnmarshalBinary(b)
+	o.V, o.Valid = n.V, n.Valid
+	return err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// Valid values are encoded as their JSON document.
+func (n JSON[T]) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender, like MarshalBinary
+func (n JSON[T]) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, binaryNull), nil
+	}
+	data, err := json.Marshal(n.V)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(b, binaryValid), data...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (n *JSON[T]) UnmarshalBinary(b []byte) error {
+	var zero T
+	n.V, n.Valid = zero, false
+	payload, valid, err := readBinary(b)
+	if err != nil || !valid {
+		return err
+	}
+	if err := json.Unmarshal(payload, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
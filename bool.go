@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"reflect"
+	"strconv"
 )
 
 // Bool defines a nullable bool
@@ -33,6 +34,35 @@ func (n *Bool) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// MarshalText implements encoding.TextMarshaler
+func (n Bool) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Bool) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendBool(b, n.Bool), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Bool) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseBool(string(b))
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Bool = v
+	n.Valid = true
+	return nil
+}
+
 // Scan implements the Scanner interface from database/sql
 func (n *Bool) Scan(src any) error {
 	// Set initial state for subsequent scans.
@@ -56,3 +86,69 @@ func (n Bool) Value() (driver.Value, error) {
 	}
 	return n.Bool, nil
 }
+
+// The logical operators of Bool follow the three-valued logic of SQL,
+// in which NULL is unknown: the result is only null when it depends
+// on an unknown operand, so that NULL AND false is false, and
+// NULL OR true is true.
+
+// IsTrue reports whether n is valid and true, like IS TRUE in SQL
+func (n Bool) IsTrue() bool {
+	return n.Valid && n.Bool
+}
+
+// IsFalse reports whether n is valid and false, like IS FALSE in SQL
+func (n Bool) IsFalse() bool {
+	return n.Valid && !n.Bool
+}
+
+// IsUnknown reports whether n is null, like IS UNKNOWN in SQL
+func (n Bool) IsUnknown() bool {
+	return !n.Valid
+}
+
+// And returns n AND o, which is false if either is false,
+// and otherwise null if either is null
+func (n Bool) And(o Bool) Bool {
+	if n.IsFalse() || o.IsFalse() {
+		return Bool{Bool: false, Valid: true}
+	}
+	if !n.Valid || !o.Valid {
+		return Bool{}
+	}
+	return Bool{Bool: true, Valid: true}
+}
+
+// Or returns n OR o, which is true if either is true,
+// and otherwise null if either is null
+func (n Bool) Or(o Bool) Bool {
+	if n.IsTrue() || o.IsTrue() {
+		return Bool{Bool: true, Valid: true}
+	}
+	if !n.Valid || !o.Valid {
+		return Bool{}
+	}
+	return Bool{Bool: false, Valid: true}
+}
+
+// Not returns NOT n, which is null if n is null
+func (n Bool) Not() Bool {
+	if !n.Valid {
+		return Bool{}
+	}
+	return Bool{Bool: !n.Bool, Valid: true}
+}
+
+// Xor returns n XOR o, which is null if either is null
+func (n Bool) Xor(o Bool) Bool {
+	if !n.Valid || !o.Valid {
+		return Bool{}
+	}
+	return Bool{Bool: n.Bool != o.Bool, Valid: true}
+}
+
+// Implies returns (NOT n) OR o, which is true if n is false or o is true,
+// and otherwise null if either is null
+func (n Bool) Implies(o Bool) Bool {
+	return n.Not().Or(o)
+}
@@ -0,0 +1,212 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// BinaryEncoding defines how a BytesAs is encoded to and decoded from
+// JSON strings and text. Implementations are used through their zero value.
+type BinaryEncoding interface {
+	// AppendEncode appends the encoding of src to dst.
+	AppendEncode(dst, src []byte) []byte
+	// DecodeString decodes s.
+	DecodeString(s string) ([]byte, error)
+}
+
+// Base64Encoding is the standard, padded base64 BinaryEncoding used by Bytes,
+// matching encoding/json for []byte.
+type Base64Encoding struct{}
+
+// AppendEncode appends the standard base64 encoding of src to dst
+func (Base64Encoding) AppendEncode(dst, src []byte) []byte {
+	return base64.StdEncoding.AppendEncode(dst, src)
+}
+
+// DecodeString decodes the standard base64 string s
+func (Base64Encoding) DecodeString(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(s)
+}
+
+// Base64URLEncoding is the URL and filename safe, padded base64 BinaryEncoding
+type Base64URLEncoding struct{}
+
+// AppendEncode appends the URL safe base64 encoding of src to dst
+func (Base64URLEncoding) AppendEncode(dst, src []byte) []byte {
+	return base64.URLEncoding.AppendEncode(dst, src)
+}
+
+// DecodeString decodes the URL safe base64 string s
+func (Base64URLEncoding) DecodeString(s string) ([]byte, error) {
+	return base64.URLEncoding.DecodeString(s)
+}
+
+// HexEncoding is the lower case hexadecimal BinaryEncoding.
+// Upper case digits are accepted when decoding.
+type HexEncoding struct{}
+
+// AppendEncode appends the hexadecimal encoding of src to dst
+func (HexEncoding) AppendEncode(dst, src []byte) []byte {
+	return hex.AppendEncode(dst, src)
+}
+
+// DecodeString decodes the hexadecimal string s
+func (HexEncoding) DecodeString(s string) ([]byte, error) {
+	return hex.DecodeString(s)
+}
+
+// Bytes defines a nullable []byte, suitable for BLOB and BYTEA columns.
+// A null Bytes is distinct from a valid, empty one.
+type Bytes struct {
+	Bytes []byte
+	Valid bool // Valid is true if Bytes is not NULL
+}
+
+// MarshalJSON for Bytes.
+// Valid values are encoded as standard base64 JSON strings, as for []byte.
+func (n Bytes) MarshalJSON() ([]byte, error) {
+	return BytesAs[Base64Encoding](n).MarshalJSON()
+}
+
+// UnmarshalJSON for Bytes
+func (n *Bytes) UnmarshalJSON(b []byte) error {
+	return (*BytesAs[Base64Encoding])(n).UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Valid values are encoded as standard base64, so a valid empty
+// value cannot be told apart from null once encoded.
+func (n Bytes) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Bytes) AppendText(b []byte) ([]byte, error) {
+	return BytesAs[Base64Encoding](n).AppendText(b)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Bytes) UnmarshalText(b []byte) error {
+	return (*BytesAs[Base64Encoding])(n).UnmarshalText(b)
+}
+
+// Scan implements the Scanner interface from database/sql.
+// The source is always copied, as drivers may reuse their buffers.
+func (n *Bytes) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Bytes, n.Valid = nil, false
+
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		n.Bytes = append([]byte{}, src...)
+	case string:
+		n.Bytes = []byte(src)
+	default:
+		return fmt.Errorf("nullable: cannot scan type %T into Bytes", src)
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value returns the database/sql driver value for Bytes
+func (n Bytes) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if n.Bytes == nil {
+		// A nil slice would be written as NULL.
+		return []byte{}, nil
+	}
+	return n.Bytes, nil
+}
+
+// BytesAs defines a nullable []byte whose JSON and text encoding is chosen by E.
+// It behaves exactly like Bytes otherwise, and Bytes is BytesAs[Base64Encoding]
+// in JSON and text.
+//
+//	type Blob struct {
+//		Checksum nullable.BytesAs[nullable.HexEncoding] `json:"checksum"`
+//	}
+type BytesAs[E BinaryEncoding] struct {
+	Bytes []byte
+	Valid bool // Valid is true if Bytes is not NULL
+}
+
+// MarshalJSON for BytesAs.
+// Valid values are encoded as JSON strings using E.
+func (n BytesAs[E]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	var e E
+	b := e.AppendEncode([]byte{'"'}, n.Bytes)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON for BytesAs
+func (n *BytesAs[E]) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Bytes, n.Valid = nil, false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		n.Valid = false
+		return err
+	}
+	var e E
+	decoded, err := e.DecodeString(s)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Bytes, n.Valid = decoded, true
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Valid values are encoded using E, so a valid empty
+// value cannot be told apart from null once encoded.
+func (n BytesAs[E]) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n BytesAs[E]) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	var e E
+	return e.AppendEncode(b, n.Bytes), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *BytesAs[E]) UnmarshalText(b []byte) error {
+	n.Bytes, n.Valid = nil, false
+	if len(b) == 0 {
+		return nil
+	}
+	var e E
+	decoded, err := e.DecodeString(string(b))
+	if err != nil {
+		return err
+	}
+	n.Bytes, n.Valid = decoded, true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql, like Bytes.Scan
+func (n *BytesAs[E]) Scan(src any) error {
+	return (*Bytes)(n).Scan(src)
+}
+
+// Value returns the database/sql driver value for BytesAs, like Bytes.Value
+func (n BytesAs[E]) Value() (driver.Value, error) {
+	return Bytes(n).Value()
+}
@@ -28,6 +28,70 @@ func MakeFloat64(i *float64) Float64 {
 	return Float64{Float64: *i, Valid: true}
 }
 
+// MakeInt32 returns a new Int32
+func MakeInt32(i *int32) Int32 {
+	if i == nil {
+		return Int32{Valid: false}
+	}
+	return Int32{Int32: *i, Valid: true}
+}
+
+// MakeInt16 returns a new Int16
+func MakeInt16(i *int16) Int16 {
+	if i == nil {
+		return Int16{Valid: false}
+	}
+	return Int16{Int16: *i, Valid: true}
+}
+
+// MakeInt8 returns a new Int8
+func MakeInt8(i *int8) Int8 {
+	if i == nil {
+		return Int8{Valid: false}
+	}
+	return Int8{Int8: *i, Valid: true}
+}
+
+// MakeUint64 returns a new Uint64
+func MakeUint64(i *uint64) Uint64 {
+	if i == nil {
+		return Uint64{Valid: false}
+	}
+	return Uint64{Uint64: *i, Valid: true}
+}
+
+// MakeUint32 returns a new Uint32
+func MakeUint32(i *uint32) Uint32 {
+	if i == nil {
+		return Uint32{Valid: false}
+	}
+	return Uint32{Uint32: *i, Valid: true}
+}
+
+// MakeUint16 returns a new Uint16
+func MakeUint16(i *uint16) Uint16 {
+	if i == nil {
+		return Uint16{Valid: false}
+	}
+	return Uint16{Uint16: *i, Valid: true}
+}
+
+// MakeUint8 returns a new Uint8
+func MakeUint8(i *uint8) Uint8 {
+	if i == nil {
+		return Uint8{Valid: false}
+	}
+	return Uint8{Uint8: *i, Valid: true}
+}
+
+// MakeFloat32 returns a new Float32
+func MakeFloat32(i *float32) Float32 {
+	if i == nil {
+		return Float32{Valid: false}
+	}
+	return Float32{Float32: *i, Valid: true}
+}
+
 // MakeBool creates a new Bool
 func MakeBool(b *bool) Bool {
 	if b == nil {
@@ -43,3 +107,27 @@ func MakeTime(t time.Time) Time {
 	}
 	return Time{Time: t, Valid: true}
 }
+
+// MakeDate creates a new Date from the calendar date of t in its own location
+func MakeDate(t time.Time) Date {
+	if t == emptyTime {
+		return Date{Valid: false}
+	}
+	return Date{Year: t.Year(), Month: t.Month(), Day: t.Day(), Valid: true}
+}
+
+// MakeDuration creates a new Duration
+func MakeDuration(d *time.Duration) Duration {
+	if d == nil {
+		return Duration{Valid: false}
+	}
+	return Duration{Duration: *d, Valid: true}
+}
+
+// MakeBytes creates a new Bytes, which is null only if b is nil
+func MakeBytes(b []byte) Bytes {
+	if b == nil {
+		return Bytes{Valid: false}
+	}
+	return Bytes{Bytes: b, Valid: true}
+}
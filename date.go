@@ -0,0 +1,151 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// dateLayout is the layout used to represent a Date in JSON and SQL.
+const dateLayout = time.DateOnly
+
+// Date defines a nullable calendar date, without time of day or location,
+// suitable for SQL DATE columns.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+	Valid bool // Valid is true if Date is not NULL
+}
+
+// MarshalJSON for Date
+func (n Date) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.format())
+}
+
+// UnmarshalJSON for Date
+func (n *Date) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		n.Valid = false
+		return err
+	}
+	tim, err := time.Parse(dateLayout, s)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.set(tim)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Date) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Date) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return n.appendFormat(b), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Date) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	tim, err := time.Parse(dateLayout, string(b))
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.set(tim)
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// It accepts time.Time values as well as strings and byte slices
+// starting with a date, optionally followed by a time of day.
+func (n *Date) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		n.set(src)
+		return nil
+	case string:
+		return n.scanString(src)
+	case []byte:
+		return n.scanString(string(src))
+	default:
+		return fmt.Errorf("nullable: cannot scan type %T into Date", src)
+	}
+}
+
+// Value returns the database/sql driver value for Date
+func (n Date) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.format(), nil
+}
+
+// In returns the Time at midnight on n in loc
+func (n Date) In(loc *time.Location) Time {
+	if !n.Valid {
+		return Time{Valid: false}
+	}
+	return Time{Time: time.Date(n.Year, n.Month, n.Day, 0, 0, 0, 0, loc), Valid: true}
+}
+
+// DateIn returns the Date on which n falls in loc
+func (n Time) DateIn(loc *time.Location) Date {
+	if !n.Valid {
+		return Date{Valid: false}
+	}
+	var d Date
+	d.set(n.Time.In(loc))
+	return d
+}
+
+func (n *Date) set(t time.Time) {
+	n.Year, n.Month, n.Day = t.Date()
+	n.Valid = true
+}
+
+func (n *Date) scanString(s string) error {
+	// Some drivers return DATE columns as full timestamps.
+	if len(s) > len(dateLayout) && (s[len(dateLayout)] == 'T' || s[len(dateLayout)] == ' ') {
+		s = s[:len(dateLayout)]
+	}
+	tim, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return err
+	}
+	n.set(tim)
+	return nil
+}
+
+func (n Date) format() string {
+	return string(n.appendFormat(nil))
+}
+
+func (n Date) appendFormat(b []byte) []byte {
+	return time.Date(n.Year, n.Month, n.Day, 0, 0, 0, 0, time.UTC).AppendFormat(b, dateLayout)
+}
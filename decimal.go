@@ -0,0 +1,363 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalScale bounds the exponents accepted by ParseDecimal,
+// so that a short input cannot require an enormous allocation.
+const maxDecimalScale = math.MaxInt16
+
+// ErrDivisionByZero is returned when dividing by zero
+var ErrDivisionByZero = errors.New("nullable: division by zero")
+
+// Decimal defines a nullable arbitrary-precision decimal number,
+// suitable for NUMERIC and DECIMAL columns.
+//
+// It is stored as an unscaled integer and a number of fractional digits,
+// so that values such as "12.3400" round-trip exactly, trailing zeros included.
+// The zero value is null; use ParseDecimal or NewDecimal to create one.
+type Decimal struct {
+	coef  *big.Int // coef is never mutated once set, nil means zero
+	scale int32    // scale is the number of digits after the decimal point
+	Valid bool     // Valid is true if Decimal is not NULL
+}
+
+// NewDecimal returns the valid Decimal unscaled * 10^-scale.
+// A negative scale multiplies unscaled by the matching power of ten.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	coef := big.NewInt(unscaled)
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: scale, Valid: true}
+}
+
+// ParseDecimal parses a decimal number such as "-12.3400" or "1.5e3".
+func ParseDecimal(s string) (Decimal, error) {
+	orig := s
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil || exp > maxDecimalScale || exp < -maxDecimalScale {
+			return Decimal{}, fmt.Errorf("nullable: invalid decimal %q", orig)
+		}
+		s = s[:i]
+	}
+
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("nullable: invalid decimal %q", orig)
+	}
+
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		coef.Neg(coef)
+	}
+	scale := int64(len(fracPart)) - exp
+	if scale > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("nullable: invalid decimal %q", orig)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale), Valid: true}, nil
+}
+
+// String returns n in plain decimal notation, never using an exponent,
+// or an empty string if n is null.
+func (n Decimal) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return string(n.appendString(nil))
+}
+
+// Scale returns the number of digits after the decimal point
+func (n Decimal) Scale() int32 {
+	return n.scale
+}
+
+// MarshalJSON for Decimal.
+// Valid decimals are encoded as JSON numbers, without exponent.
+func (n Decimal) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.appendString(nil), nil
+}
+
+// UnmarshalJSON for Decimal.
+// It accepts both JSON numbers and strings holding a number.
+func (n *Decimal) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
+		b = b[1 : len(b)-1]
+	}
+	d, err := ParseDecimal(string(b))
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	*n = d
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Decimal) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Decimal) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return n.appendString(b), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Decimal) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	d, err := ParseDecimal(string(b))
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	*n = d
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql
+func (n *Decimal) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var (
+		d   Decimal
+		err error
+	)
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		d, err = ParseDecimal(src)
+	case []byte:
+		d, err = ParseDecimal(string(src))
+	case int64:
+		d = NewDecimal(src, 0)
+	case float64:
+		d, err = ParseDecimal(strconv.FormatFloat(src, 'f', -1, 64))
+	default:
+		err = fmt.Errorf("nullable: cannot scan type %T into Decimal", src)
+	}
+	if err != nil {
+		return err
+	}
+	*n = d
+	return nil
+}
+
+// Value returns the database/sql driver value for Decimal
+func (n Decimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.String(), nil
+}
+
+// Add returns n + o, which is null if either operand is null
+func (n Decimal) Add(o Decimal) Decimal {
+	if !n.Valid || !o.Valid {
+		return Decimal{}
+	}
+	scale, a, b := align(n, o)
+	return Decimal{coef: a.Add(a, b), scale: scale, Valid: true}
+}
+
+// Sub returns n - o, which is null if either operand is null
+func (n Decimal) Sub(o Decimal) Decimal {
+	if !n.Valid || !o.Valid {
+		return Decimal{}
+	}
+	scale, a, b := align(n, o)
+	return Decimal{coef: a.Sub(a, b), scale: scale, Valid: true}
+}
+
+// Mul returns n * o, which is null if either operand is null
+func (n Decimal) Mul(o Decimal) Decimal {
+	if !n.Valid || !o.Valid {
+		return Decimal{}
+	}
+	coef := new(big.Int).Mul(n.int(), o.int())
+	return Decimal{coef: coef, scale: n.scale + o.scale, Valid: true}
+}
+
+// Quo returns n / o rounded to scale fractional digits, half away from zero.
+// The result is null if either operand is null.
+// Dividing by zero returns ErrDivisionByZero, as PostgreSQL does; see NullIf.
+func (n Decimal) Quo(o Decimal, scale int32) (Decimal, error) {
+	if !n.Valid || !o.Valid {
+		return Decimal{}, nil
+	}
+	scale = max(scale, 0)
+	if o.int().Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	r := new(big.Rat).SetFrac(n.int(), o.int())
+	r.Mul(r, new(big.Rat).SetFrac(pow10(o.scale+scale), pow10(n.scale)))
+	return Decimal{coef: roundRat(r), scale: scale, Valid: true}, nil
+}
+
+// NullIf returns null if n equals v numerically, and n otherwise, like NULLIF(n, v) in SQL.
+// Dividing by o.NullIf(zero) returns null rather than ErrDivisionByZero, as MySQL does.
+func (n Decimal) NullIf(v Decimal) Decimal {
+	if c, ok := n.Cmp(v); ok && c == 0 {
+		return Decimal{}
+	}
+	return n
+}
+
+// Neg returns -n, which is null if n is null
+func (n Decimal) Neg() Decimal {
+	if !n.Valid {
+		return Decimal{}
+	}
+	return Decimal{coef: new(big.Int).Neg(n.int()), scale: n.scale, Valid: true}
+}
+
+// Abs returns |n|, which is null if n is null
+func (n Decimal) Abs() Decimal {
+	if !n.Valid {
+		return Decimal{}
+	}
+	return Decimal{coef: new(big.Int).Abs(n.int()), scale: n.scale, Valid: true}
+}
+
+// Round returns n rounded to scale fractional digits, half away from zero.
+// Values which already have fewer fractional digits are returned unchanged.
+func (n Decimal) Round(scale int32) Decimal {
+	scale = max(scale, 0)
+	if !n.Valid || scale >= n.scale {
+		return n
+	}
+	r := new(big.Rat).SetFrac(n.int(), pow10(n.scale-scale))
+	return Decimal{coef: roundRat(r), scale: scale, Valid: true}
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of n.
+// ok is false if n is null.
+func (n Decimal) Sign() (sign int, ok bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.int().Sign(), true
+}
+
+// Cmp compares n and o, returning -1, 0 or +1, regardless of their scales.
+// ok is false if either operand is null, as SQL comparisons with NULL are unknown.
+func (n Decimal) Cmp(o Decimal) (cmp int, ok bool) {
+	if !n.Valid || !o.Valid {
+		return 0, false
+	}
+	_, a, b := align(n, o)
+	return a.Cmp(b), true
+}
+
+// Equal reports whether n and o are both null, or both valid and numerically equal
+func (n Decimal) Equal(o Decimal) bool {
+	if !n.Valid || !o.Valid {
+		return n.Valid == o.Valid
+	}
+	cmp, _ := n.Cmp(o)
+	return cmp == 0
+}
+
+func (n Decimal) int() *big.Int {
+	if n.coef == nil {
+		return new(big.Int)
+	}
+	return n.coef
+}
+
+// appendString appends n to b in place, without allocating
+// unless the coefficient does not fit in an int64.
+func (n Decimal) appendString(b []byte) []byte {
+	start := len(b)
+	switch {
+	case n.coef == nil:
+		b = append(b, '0')
+	case n.coef.IsInt64():
+		b = strconv.AppendInt(b, n.coef.Int64(), 10)
+	default:
+		b = n.coef.Append(b, 10)
+	}
+	if n.scale <= 0 {
+		return b
+	}
+	if b[start] == '-' {
+		start++
+	}
+
+	// Pad with zeros, so that there is a digit before the point.
+	scale := int(n.scale)
+	digits := len(b) - start
+	if pad := scale + 1 - digits; pad > 0 {
+		b = append(b, make([]byte, pad)...)
+		copy(b[start+pad:], b[start:start+digits])
+		for i := start; i < start+pad; i++ {
+			b[i] = '0'
+		}
+	}
+	return slices.Insert(b, len(b)-scale, '.')
+}
+
+// align returns copies of the coefficients of a and b,
+// brought to the larger of their two scales.
+func align(a, b Decimal) (int32, *big.Int, *big.Int) {
+	x, y := new(big.Int).Set(a.int()), new(big.Int).Set(b.int())
+	switch {
+	case a.scale > b.scale:
+		y.Mul(y, pow10(a.scale-b.scale))
+		return a.scale, x, y
+	case b.scale > a.scale:
+		x.Mul(x, pow10(b.scale-a.scale))
+		return b.scale, x, y
+	}
+	return a.scale, x, y
+}
+
+// roundRat rounds r to the nearest integer, half away from zero.
+func roundRat(r *big.Rat) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Abs(m).Lsh(m, 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Num().Sign())))
+	}
+	return q
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,365 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration defines a nullable time.Duration
+type Duration struct {
+	Duration time.Duration
+	Valid    bool // Valid is true if Duration is not NULL
+}
+
+// MarshalJSON for Duration.
+// Valid durations are encoded as Go duration strings, such as "1h30m0s".
+func (n Duration) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Duration.String())
+}
+
+// UnmarshalJSON for Duration.
+// It accepts Go duration strings as well as ISO-8601 ones, such as "PT1H30M".
+func (n *Duration) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		n.Valid = false
+		return err
+	}
+	d, err := parseDurationString(s)
+	n.Duration = d
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Duration) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Duration) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return append(b, n.Duration.String()...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Duration) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	d, err := parseDurationString(string(b))
+	n.Duration = d
+	n.Valid = err == nil
+	return err
+}
+
+// Scan implements the Scanner interface from database/sql.
+// int64 values are taken as a number of milliseconds, while strings and byte
+// slices may hold Postgres intervals, ISO-8601 or Go duration strings.
+func (n *Duration) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var (
+		d   time.Duration
+		err error
+	)
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case int64:
+		if src > math.MaxInt64/int64(time.Millisecond) || src < math.MinInt64/int64(time.Millisecond) {
+			err = fmt.Errorf("nullable: %d milliseconds out of range for Duration", src)
+		}
+		d = time.Duration(src) * time.Millisecond
+	case string:
+		d, err = parseDuration(src)
+	case []byte:
+		d, err = parseDuration(string(src))
+	default:
+		err = fmt.Errorf("nullable: cannot scan type %T into Duration", src)
+	}
+	if err != nil {
+		return err
+	}
+	n.Duration = d
+	n.Valid = true
+	return nil
+}
+
+// Value returns the database/sql driver value for Duration, as an ISO-8601
+// string such as "PT1H30M", which Postgres accepts for INTERVAL columns.
+func (n Duration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return formatISO8601Duration(n.Duration), nil
+}
+
+// Millis returns a driver.Valuer which writes n as an int64 number of
+// milliseconds, as Scan reads it, for columns which store durations as integers.
+func (n Duration) Millis() driver.Valuer {
+	return durationMillis(n)
+}
+
+// durationMillis is the driver.Valuer returned by Duration.Millis
+type durationMillis Duration
+
+// Value returns n.Duration in milliseconds, or nil if n is null
+func (n durationMillis) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Duration.Milliseconds(), nil
+}
+
+// parseDuration parses any of the supported textual duration representations.
+func parseDuration(s string) (time.Duration, error) {
+	if isISO8601Duration(s) {
+		return parseISO8601Duration(s)
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	return parsePostgresInterval(s)
+}
+
+// parseDurationString parses Go and ISO-8601 duration strings.
+func parseDurationString(s string) (time.Duration, error) {
+	if isISO8601Duration(s) {
+		return parseISO8601Duration(s)
+	}
+	return time.ParseDuration(s)
+}
+
+func isISO8601Duration(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	return strings.HasPrefix(s, "P")
+}
+
+// formatISO8601Duration formats d using hours, minutes and seconds only,
+// since days are not always 24 hours long for the database.
+func formatISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	sign := ""
+	if d < 0 {
+		sign = "-"
+	}
+	abs := d.Abs()
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if h := abs / time.Hour; h > 0 {
+		b.WriteString(sign + strconv.FormatInt(int64(h), 10) + "H")
+	}
+	if m := abs % time.Hour / time.Minute; m > 0 {
+		b.WriteString(sign + strconv.FormatInt(int64(m), 10) + "M")
+	}
+	if s := abs % time.Minute; s > 0 {
+		b.WriteString(sign + strconv.FormatFloat(s.Seconds(), 'f', -1, 64) + "S")
+	}
+	return b.String()
+}
+
+// parseISO8601Duration parses durations such as "P1DT2H30M" or "-PT1.5S".
+// Years and months are rejected, as their length is not fixed.
+func parseISO8601Duration(s string) (time.Duration, error) {
+	orig := s
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) == 1 {
+		return 0, fmt.Errorf("nullable: invalid ISO-8601 duration %q", orig)
+	}
+	s = s[1:]
+
+	var (
+		d      time.Duration
+		inTime bool
+	)
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return 0, fmt.Errorf("nullable: invalid ISO-8601 duration %q", orig)
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+
+		i := strings.IndexAny(s, "WDHMS")
+		if i <= 0 {
+			return 0, fmt.Errorf("nullable: invalid ISO-8601 duration %q", orig)
+		}
+		f, err := strconv.ParseFloat(strings.Replace(s[:i], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("nullable: invalid ISO-8601 duration %q: %w", orig, err)
+		}
+
+		var unit time.Duration
+		switch designator := s[i]; {
+		case !inTime && designator == 'W':
+			unit = 7 * 24 * time.Hour
+		case !inTime && designator == 'D':
+			unit = 24 * time.Hour
+		case inTime && designator == 'H':
+			unit = time.Hour
+		case inTime && designator == 'M':
+			unit = time.Minute
+		case inTime && designator == 'S':
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("nullable: unsupported ISO-8601 duration %q", orig)
+		}
+		var ok bool
+		if d, ok = addDuration(d, f, unit); !ok {
+			return 0, fmt.Errorf("nullable: ISO-8601 duration %q out of range", orig)
+		}
+		s = s[i+1:]
+	}
+
+	if neg {
+		if d == math.MinInt64 {
+			return 0, fmt.Errorf("nullable: ISO-8601 duration %q out of range", orig)
+		}
+		d = -d
+	}
+	return d, nil
+}
+
+// parsePostgresInterval parses intervals in the default Postgres output
+// style, such as "1 day 02:03:04.5" or "-3 days +01:00:00".
+// Years and months are rejected, as their length is not fixed.
+func parsePostgresInterval(s string) (time.Duration, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("nullable: invalid interval %q", s)
+	}
+
+	var d time.Duration
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Contains(field, ":") {
+			clock, err := parseIntervalClock(field)
+			if err != nil {
+				return 0, fmt.Errorf("nullable: invalid interval %q: %w", s, err)
+			}
+			var ok bool
+			if d, ok = checkedAdd(d, clock); !ok {
+				return 0, fmt.Errorf("nullable: interval %q out of range", s)
+			}
+			continue
+		}
+
+		if i+1 >= len(fields) {
+			return 0, fmt.Errorf("nullable: invalid interval %q", s)
+		}
+		f, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return 0, fmt.Errorf("nullable: invalid interval %q: %w", s, err)
+		}
+
+		var unit time.Duration
+		switch strings.TrimSuffix(fields[i+1], "s") {
+		case "week":
+			unit = 7 * 24 * time.Hour
+		case "day":
+			unit = 24 * time.Hour
+		case "hour":
+			unit = time.Hour
+		case "min", "minute":
+			unit = time.Minute
+		case "sec", "second":
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("nullable: unsupported interval %q", s)
+		}
+		var ok bool
+		if d, ok = addDuration(d, f, unit); !ok {
+			return 0, fmt.Errorf("nullable: interval %q out of range", s)
+		}
+		i++
+	}
+	return d, nil
+}
+
+// parseIntervalClock parses the [-+]HH:MM[:SS[.fff]] part of an interval.
+func parseIntervalClock(s string) (time.Duration, error) {
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid clock %q", s)
+	}
+
+	h, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if h < 0 || m < 0 || h > int64(math.MaxInt64/time.Hour) || m > int64(math.MaxInt64/time.Minute) {
+		return 0, fmt.Errorf("clock %q out of range", s)
+	}
+	d, ok := checkedAdd(time.Duration(h)*time.Hour, time.Duration(m)*time.Minute)
+	if len(parts) == 3 && ok {
+		sec, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return 0, err
+		}
+		d, ok = addDuration(d, sec, time.Second)
+	}
+	if !ok {
+		return 0, fmt.Errorf("clock %q out of range", s)
+	}
+
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// addDuration returns d + f*unit, rounded to the nanosecond.
+// It reports false if the result does not fit in a time.Duration.
+func addDuration(d time.Duration, f float64, unit time.Duration) (time.Duration, bool) {
+	x := math.Round(f * float64(unit))
+	// float64(math.MaxInt64) rounds up to 2^63, which is itself out of range.
+	if math.IsNaN(x) || x >= math.MaxInt64 || x < math.MinInt64 {
+		return 0, false
+	}
+	return checkedAdd(d, time.Duration(x))
+}
+
+// checkedAdd returns a + b, reporting false if the sum overflows.
+func checkedAdd(a, b time.Duration) (time.Duration, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
@@ -0,0 +1,93 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Float32 defines a nullable float32
+type Float32 struct {
+	Float32 float32
+	Valid   bool // Valid is true if Float32 is not NULL
+}
+
+// MarshalJSON for Float32
+func (n Float32) MarshalJSON() ([]byte, error) {
+	var a *float32
+	if n.Valid {
+		a = &n.Float32
+	}
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON for Float32.
+// Values which do not fit in a float32 are rejected.
+func (n *Float32) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Float32)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Float32) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Float32) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendFloat(b, float64(n.Float32), 'g', -1, 32), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Float32) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseFloat(string(b), 32)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Float32 = float32(v)
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Values which do not fit in a float32 are rejected.
+func (n *Float32) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var a sql.Null[float32]
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	n.Float32 = a.V
+	if reflect.TypeOf(src) != nil {
+		n.Valid = true
+	}
+	return nil
+}
+
+// Value returns the database/sql driver value for Float32
+func (n Float32) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	// Go through the shortest decimal representation so that
+	// 0.1 is written as 0.1 rather than 0.10000000149011612.
+	return strconv.ParseFloat(strconv.FormatFloat(float64(n.Float32), 'g', -1, 32), 64)
+}
@@ -2,10 +2,13 @@ package nullable
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"reflect"
+	"strconv"
 )
 
 // Float64 aliases sql.Float64
@@ -34,6 +37,35 @@ func (n *Float64) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// MarshalText implements encoding.TextMarshaler
+func (n Float64) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Float64) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendFloat(b, n.Float64, 'g', -1, 64), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Float64) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Float64 = v
+	n.Valid = true
+	return nil
+}
+
 // Scan implements the Scanner interface from database/sql
 func (n *Float64) Scan(src any) error {
 	// Set initial state for subsequent scans.
@@ -57,3 +89,96 @@ func (n Float64) Value() (driver.Value, error) {
 	}
 	return n.Float64, nil
 }
+
+// Add returns n + o, which is null if either operand is null.
+// It returns ErrOverflow if finite operands give an infinite result.
+func (n Float64) Add(o Float64) (Float64, error) {
+	if !n.Valid || !o.Valid {
+		return Float64{}, nil
+	}
+	return checkedFloat64(n.Float64+o.Float64, n.Float64, o.Float64)
+}
+
+// Sub returns n - o, which is null if either operand is null.
+// It returns ErrOverflow if finite operands give an infinite result.
+func (n Float64) Sub(o Float64) (Float64, error) {
+	if !n.Valid || !o.Valid {
+		return Float64{}, nil
+	}
+	return checkedFloat64(n.Float64-o.Float64, n.Float64, o.Float64)
+}
+
+// Mul returns n * o, which is null if either operand is null.
+// It returns ErrOverflow if finite operands give an infinite result.
+func (n Float64) Mul(o Float64) (Float64, error) {
+	if !n.Valid || !o.Valid {
+		return Float64{}, nil
+	}
+	return checkedFloat64(n.Float64*o.Float64, n.Float64, o.Float64)
+}
+
+// Div returns n / o, which is null if either operand is null.
+// Dividing by zero returns ErrDivisionByZero, as PostgreSQL does; see NullIf.
+// It returns ErrOverflow if finite operands give an infinite result.
+func (n Float64) Div(o Float64) (Float64, error) {
+	if !n.Valid || !o.Valid {
+		return Float64{}, nil
+	}
+	if o.Float64 == 0 {
+		return Float64{}, ErrDivisionByZero
+	}
+	return checkedFloat64(n.Float64/o.Float64, n.Float64, o.Float64)
+}
+
+// NullIf returns null if n equals v, and n otherwise, like NULLIF(n, v) in SQL.
+// Dividing by o.NullIf(0) returns null rather than ErrDivisionByZero, as MySQL does.
+func (n Float64) NullIf(v float64) Float64 {
+	if n.Valid && n.Float64 == v {
+		return Float64{}
+	}
+	return n
+}
+
+// checkedFloat64 returns a valid Float64 of v, or ErrOverflow if v is
+// infinite although neither operand a nor b is, as PostgreSQL reports
+// "value out of range: overflow" for double precision.
+func checkedFloat64(v, a, b float64) (Float64, error) {
+	if math.IsInf(v, 0) && !math.IsInf(a, 0) && !math.IsInf(b, 0) {
+		return Float64{}, ErrOverflow
+	}
+	return Float64{Float64: v, Valid: true}, nil
+}
+
+// Cmp compares n and o, returning -1, 0 or +1.
+// It reports false if either operand is null, as SQL comparisons with NULL are unknown.
+// As with cmp.Compare, NaN is less than any other number, and equal to itself.
+func (n Float64) Cmp(o Float64) (int, bool) {
+	if !n.Valid || !o.Valid {
+		return 0, false
+	}
+	return cmp.Compare(n.Float64, o.Float64), true
+}
+
+// Min returns the smaller of n and o, which is null if either operand is null,
+// as LEAST is in MySQL. The Min function ignores nulls, as LEAST does in PostgreSQL.
+// It orders NaN as Cmp does.
+func (n Float64) Min(o Float64) Float64 {
+	if c, ok := n.Cmp(o); !ok {
+		return Float64{}
+	} else if c > 0 {
+		return o
+	}
+	return n
+}
+
+// Max returns the larger of n and o, which is null if either operand is null,
+// as GREATEST is in MySQL. The Max function ignores nulls, as GREATEST does in PostgreSQL.
+// It orders NaN as Cmp does.
+func (n Float64) Max(o Float64) Float64 {
+	if c, ok := n.Cmp(o); !ok {
+		return Float64{}
+	} else if c < 0 {
+		return o
+	}
+	return n
+}
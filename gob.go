@@ -0,0 +1,255 @@
+package nullable
+
+// Every type in this package implements gob.GobEncoder and gob.GobDecoder
+// using its binary encoding, so that null values take a single byte, and
+// none at all when gob leaves out zero struct fields.
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n String) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *String) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Int64) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Int64) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Int32) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Int32) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Int16) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Int16) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Int8) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Int8) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Uint64) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Uint64) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Uint32) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Uint32) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Uint16) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Uint16) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Uint8) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Uint8) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Float64) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Float64) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Float32) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Float32) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Bool) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Bool) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Time) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Time) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n FormattedTime[F]) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *FormattedTime[F]) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Date) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Date) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n TimeOfDay) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *TimeOfDay) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Duration) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Duration) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n UUID) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *UUID) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Decimal) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Decimal) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Bytes) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Bytes) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n BytesAs[E]) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *BytesAs[E]) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n RawJSON) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *RawJSON) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n Null[T]) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *Null[T]) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (o Optional[T]) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (o *Optional[T]) GobDecode(b []byte) error {
+	return o.UnmarshalBinary(b)
+}
+
+// GobEncode implements gob.GobEncoder, like MarshalBinary
+func (n JSON[T]) GobEncode() ([]byte, error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, like UnmarshalBinary
+func (n *JSON[T]) GobDecode(b []byte) error {
+	return n.UnmarshalBinary(b)
+}
@@ -0,0 +1,91 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Int16 defines a nullable int16
+type Int16 struct {
+	Int16 int16
+	Valid bool // Valid is true if Int16 is not NULL
+}
+
+// MarshalJSON for Int16
+func (n Int16) MarshalJSON() ([]byte, error) {
+	var a *int16
+	if n.Valid {
+		a = &n.Int16
+	}
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON for Int16.
+// Values which do not fit in an int16 are rejected.
+func (n *Int16) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Int16)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Int16) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Int16) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, int64(n.Int16), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Int16) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseInt(string(b), 10, 16)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Int16 = int16(v)
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Values which do not fit in an int16 are rejected.
+func (n *Int16) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var a sql.Null[int16]
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	n.Int16 = a.V
+	if reflect.TypeOf(src) != nil {
+		n.Valid = true
+	}
+	return nil
+}
+
+// Value returns the database/sql driver value for Int16
+func (n Int16) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Int16), nil
+}
@@ -0,0 +1,91 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Int32 defines a nullable int32
+type Int32 struct {
+	Int32 int32
+	Valid bool // Valid is true if Int32 is not NULL
+}
+
+// MarshalJSON for Int32
+func (n Int32) MarshalJSON() ([]byte, error) {
+	var a *int32
+	if n.Valid {
+		a = &n.Int32
+	}
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON for Int32.
+// Values which do not fit in an int32 are rejected.
+func (n *Int32) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Int32)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Int32) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Int32) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, int64(n.Int32), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Int32) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseInt(string(b), 10, 32)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Int32 = int32(v)
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Values which do not fit in an int32 are rejected.
+func (n *Int32) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var a sql.Null[int32]
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	n.Int32 = a.V
+	if reflect.TypeOf(src) != nil {
+		n.Valid = true
+	}
+	return nil
+}
+
+// Value returns the database/sql driver value for Int32
+func (n Int32) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Int32), nil
+}
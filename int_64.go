@@ -2,10 +2,13 @@ package nullable
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"reflect"
+	"strconv"
 )
 
 // Int64 defines a nullable int64
@@ -34,6 +37,35 @@ func (n *Int64) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// MarshalText implements encoding.TextMarshaler
+func (n Int64) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Int64) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, n.Int64, 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Int64) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Int64 = v
+	n.Valid = true
+	return nil
+}
+
 // Scan implements the Scanner interface from database/sql
 func (n *Int64) Scan(src any) error {
 	// Set initial state for subsequent scans.
@@ -57,3 +89,90 @@ func (n Int64) Value() (driver.Value, error) {
 	}
 	return n.Int64, nil
 }
+
+// Add returns n + o, which is null if either operand is null.
+// It returns ErrOverflow if the result does not fit in an int64.
+func (n Int64) Add(o Int64) (Int64, error) {
+	if !n.Valid || !o.Valid {
+		return Int64{}, nil
+	}
+	return checkedInt64(addInt64(n.Int64, o.Int64))
+}
+
+// Sub returns n - o, which is null if either operand is null.
+// It returns ErrOverflow if the result does not fit in an int64.
+func (n Int64) Sub(o Int64) (Int64, error) {
+	if !n.Valid || !o.Valid {
+		return Int64{}, nil
+	}
+	return checkedInt64(subInt64(n.Int64, o.Int64))
+}
+
+// Mul returns n * o, which is null if either operand is null.
+// It returns ErrOverflow if the result does not fit in an int64.
+func (n Int64) Mul(o Int64) (Int64, error) {
+	if !n.Valid || !o.Valid {
+		return Int64{}, nil
+	}
+	return checkedInt64(mulInt64(n.Int64, o.Int64))
+}
+
+// Div returns n / o, truncated toward zero, which is null if either operand is null.
+// Dividing by zero returns ErrDivisionByZero, as PostgreSQL does; see NullIf.
+// Dividing math.MinInt64 by -1 returns ErrOverflow.
+func (n Int64) Div(o Int64) (Int64, error) {
+	if !n.Valid || !o.Valid {
+		return Int64{}, nil
+	}
+	if o.Int64 == 0 {
+		return Int64{}, ErrDivisionByZero
+	}
+	if n.Int64 == math.MinInt64 && o.Int64 == -1 {
+		return Int64{}, ErrOverflow
+	}
+	return Int64{Int64: n.Int64 / o.Int64, Valid: true}, nil
+}
+
+// NullIf returns null if n equals v, and n otherwise, like NULLIF(n, v) in SQL.
+// Dividing by o.NullIf(0) returns null rather than ErrDivisionByZero, as MySQL does.
+func (n Int64) NullIf(v int64) Int64 {
+	if n.Valid && n.Int64 == v {
+		return Int64{}
+	}
+	return n
+}
+
+// checkedInt64 returns a valid Int64 of v, or ErrOverflow if ok is false
+func checkedInt64(v int64, ok bool) (Int64, error) {
+	if !ok {
+		return Int64{}, ErrOverflow
+	}
+	return Int64{Int64: v, Valid: true}, nil
+}
+
+// Cmp compares n and o, returning -1, 0 or +1.
+// It reports false if either operand is null, as SQL comparisons with NULL are unknown.
+func (n Int64) Cmp(o Int64) (int, bool) {
+	if !n.Valid || !o.Valid {
+		return 0, false
+	}
+	return cmp.Compare(n.Int64, o.Int64), true
+}
+
+// Min returns the smaller of n and o, which is null if either operand is null,
+// as LEAST is in MySQL. The Min function ignores nulls, as LEAST does in PostgreSQL.
+func (n Int64) Min(o Int64) Int64 {
+	if !n.Valid || !o.Valid {
+		return Int64{}
+	}
+	return Int64{Int64: min(n.Int64, o.Int64), Valid: true}
+}
+
+// Max returns the larger of n and o, which is null if either operand is null,
+// as GREATEST is in MySQL. The Max function ignores nulls, as GREATEST does in PostgreSQL.
+func (n Int64) Max(o Int64) Int64 {
+	if !n.Valid || !o.Valid {
+		return Int64{}
+	}
+	return Int64{Int64: max(n.Int64, o.Int64), Valid: true}
+}
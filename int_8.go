@@ -0,0 +1,91 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Int8 defines a nullable int8
+type Int8 struct {
+	Int8  int8
+	Valid bool // Valid is true if Int8 is not NULL
+}
+
+// MarshalJSON for Int8
+func (n Int8) MarshalJSON() ([]byte, error) {
+	var a *int8
+	if n.Valid {
+		a = &n.Int8
+	}
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON for Int8.
+// Values which do not fit in an int8 are rejected.
+func (n *Int8) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Int8)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Int8) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Int8) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, int64(n.Int8), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Int8) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseInt(string(b), 10, 8)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Int8 = int8(v)
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Values which do not fit in an int8 are rejected.
+func (n *Int8) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var a sql.Null[int8]
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	n.Int8 = a.V
+	if reflect.TypeOf(src) != nil {
+		n.Valid = true
+	}
+	return nil
+}
+
+// Value returns the database/sql driver value for Int8
+func (n Int8) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Int8), nil
+}
@@ -0,0 +1,115 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON defines a nullable column holding a T encoded as JSON,
+// suitable for json and jsonb columns.
+//
+// In JSON payloads the value is embedded directly, as if it were a T.
+type JSON[T any] struct {
+	V     T
+	Valid bool // Valid is true if V is not NULL
+}
+
+// MarshalJSON for JSON
+func (n JSON[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON for JSON
+func (n *JSON[T]) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.V)
+	n.Valid = err == nil
+	return err
+}
+
+// IsZero reports whether n is null, so that fields tagged
+// with omitzero are left out of the encoded output entirely.
+func (n JSON[T]) IsZero() bool {
+	return !n.Valid
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Valid values are encoded as a JSON document.
+func (n JSON[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return json.Marshal(n.V)
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n JSON[T]) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	data, err := json.Marshal(n.V)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, data...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Non-empty text must be a JSON document.
+func (n *JSON[T]) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	return n.UnmarshalJSON(b)
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Both SQL NULL and a JSON null document are scanned as null.
+func (n *JSON[T]) Scan(src any) error {
+	var zero T
+	n.V, n.Valid = zero, false
+
+	var b []byte
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		b = []byte(src)
+	case []byte:
+		b = src
+	default:
+		return fmt.Errorf("nullable: cannot scan type %T into JSON", src)
+	}
+	if bytes.Equal(bytes.TrimSpace(b), nullLiteral) {
+		return nil
+	}
+	if err := json.Unmarshal(b, &n.V); err != nil {
+		n.V = zero
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value returns the database/sql driver value for JSON
+func (n JSON[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	b, err := json.Marshal(n.V)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
@@ -16,7 +16,7 @@ type Null[T any] struct {
 // MarshalJSON for Null
 func (n Null[T]) MarshalJSON() ([]byte, error) {
 	if !n.Valid {
-		return nil, nil
+		return []byte("null"), nil
 	}
 	return json.Marshal(n.V)
 }
@@ -32,8 +32,55 @@ func (n *Null[T]) UnmarshalJSON(b []byte) error {
 	return err
 }
 
-// Scan implements the Scanner interface from database/sql
+// IsZero reports whether n is null, so that fields tagged
+// with omitzero are left out of the encoded output entirely.
+func (n Null[T]) IsZero() bool {
+	return !n.Valid
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It delegates to T if it implements encoding.TextMarshaler, formats
+// strings, booleans and numbers directly, and encodes other T as JSON.
+func (n Null[T]) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Null[T]) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return appendText(b, n.V)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It delegates to T if it implements encoding.TextUnmarshaler, parses
+// strings, booleans and numbers directly, and decodes other T as JSON.
+func (n *Null[T]) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	err := unmarshalText(&n.V, b)
+	n.Valid = err == nil
+	return err
+}
+
+// Scan implements the Scanner interface from database/sql.
+// If T implements sql.Scanner itself, non-NULL values are delegated to it.
 func (n *Null[T]) Scan(src any) error {
+	if src != nil {
+		if scanner, ok := any(&n.V).(sql.Scanner); ok {
+			if err := scanner.Scan(src); err != nil {
+				n.Valid = false
+				return err
+			}
+			n.Valid = true
+			return nil
+		}
+	}
+
 	t := &sql.Null[T]{
 		V:     n.V,
 		Valid: n.Valid,
@@ -48,8 +95,12 @@ func (n *Null[T]) Scan(src any) error {
 	return nil
 }
 
-// Value returns the database/sql driver value for Null
+// Value returns the database/sql driver value for Null.
+// If T implements driver.Valuer itself, valid values are delegated to it.
 func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
 	if valuer, ok := any(n.V).(driver.Valuer); ok {
 		return valuer.Value()
 	}
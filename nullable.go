@@ -0,0 +1,439 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
+	"time"
+)
+
+// Nullable is implemented by every type in this package, T being the Go type
+// of its value, so that generic code can handle any of them. Pointers to those
+// types additionally implement sql.Scanner and json.Unmarshaler.
+//
+// The value of most types is their own field. Otherwise it is:
+//
+//	Date        the time.Time at midnight UTC on that date
+//	TimeOfDay   the time.Duration since midnight
+//	Decimal     an exact *big.Rat
+//	RawJSON     json.RawMessage
+type Nullable[T any] interface {
+	// IsValid reports whether the value is not null.
+	IsValid() bool
+	// Get returns the value and whether it is valid.
+	// The value of a null is the zero value of T.
+	Get() (T, bool)
+
+	driver.Valuer
+	json.Marshaler
+}
+
+var (
+	_ Nullable[string]          = String{}
+	_ Nullable[int64]           = Int64{}
+	_ Nullable[int32]           = Int32{}
+	_ Nullable[int16]           = Int16{}
+	_ Nullable[int8]            = Int8{}
+	_ Nullable[uint64]          = Uint64{}
+	_ Nullable[uint32]          = Uint32{}
+	_ Nullable[uint16]          = Uint16{}
+	_ Nullable[uint8]           = Uint8{}
+	_ Nullable[float64]         = Float64{}
+	_ Nullable[float32]         = Float32{}
+	_ Nullable[bool]            = Bool{}
+	_ Nullable[time.Time]       = Time{}
+	_ Nullable[time.Time]       = FormattedTime[DefaultTimeFormat]{}
+	_ Nullable[time.Time]       = Date{}
+	_ Nullable[time.Duration]   = TimeOfDay{}
+	_ Nullable[time.Duration]   = Duration{}
+	_ Nullable[[16]byte]        = UUID{}
+	_ Nullable[*big.Rat]        = Decimal{}
+	_ Nullable[[]byte]          = Bytes{}
+	_ Nullable[[]byte]          = BytesAs[HexEncoding]{}
+	_ Nullable[json.RawMessage] = RawJSON(nil)
+	_ Nullable[any]             = Null[any]{}
+	_ Nullable[any]             = Optional[any]{}
+	_ Nullable[any]             = JSON[any]{}
+)
+
+// From returns the value of any implementation of Nullable as a Null,
+// so that it can be passed to Map and the other combinators taking a Null
+func From[T any](n Nullable[T]) Null[T] {
+	v, ok := n.Get()
+	return Null[T]{V: v, Valid: ok}
+}
+
+// Map returns f applied to the value of n, or null if n is null
+func Map[T, U any](n Null[T], f func(T) U) Null[U] {
+	if !n.Valid {
+		return Null[U]{}
+	}
+	return Null[U]{V: f(n.V), Valid: true}
+}
+
+// FlatMap returns f applied to the value of n, or null if n is null.
+// Unlike Map, f may itself return null.
+func FlatMap[T, U any](n Null[T], f func(T) Null[U]) Null[U] {
+	if !n.Valid {
+		return Null[U]{}
+	}
+	return f(n.V)
+}
+
+// Bind is an alias for FlatMap, under the name it has in functional languages
+func Bind[T, U any](n Null[T], f func(T) Null[U]) Null[U] {
+	return FlatMap(n, f)
+}
+
+// Filter returns n if it is valid and keep reports true for its value,
+// and null otherwise
+func Filter[T any](n Null[T], keep func(T) bool) Null[T] {
+	if !n.Valid || !keep(n.V) {
+		return Null[T]{}
+	}
+	return n
+}
+
+// Zip2 returns f applied to the values of a and b, or null if either is null
+func Zip2[A, B, R any](a Null[A], b Null[B], f func(A, B) R) Null[R] {
+	if !a.Valid || !b.Valid {
+		return Null[R]{}
+	}
+	return Null[R]{V: f(a.V, b.V), Valid: true}
+}
+
+// Zip3 returns f applied to the values of a, b and c, or null if any is null
+func Zip3[A, B, C, R any](a Null[A], b Null[B], c Null[C], f func(A, B, C) R) Null[R] {
+	if !a.Valid || !b.Valid || !c.Valid {
+		return Null[R]{}
+	}
+	return Null[R]{V: f(a.V, b.V, c.V), Valid: true}
+}
+
+// Coalesce returns the first valid value, or null if there is none,
+// like COALESCE in SQL
+func Coalesce[T any](values ...Null[T]) Null[T] {
+	for _, v := range values {
+		if v.Valid {
+			return v
+		}
+	}
+	return Null[T]{}
+}
+
+// FirstValid returns the first valid value and whether there is one,
+// like Coalesce
+func FirstValid[T any](values ...Null[T]) (T, bool) {
+	return Coalesce(values...).Get()
+}
+
+// Or returns the value of n if it is valid, and that of other otherwise
+func Or[T any](n, other Nullable[T]) Null[T] {
+	if v, ok := n.Get(); ok {
+		return Null[T]{V: v, Valid: true}
+	}
+	v, ok := other.Get()
+	return Null[T]{V: v, Valid: ok}
+}
+
+// Equal reports whether a and b are both null, or both valid with equal values.
+// Values are compared with their Equal method if they have one, as time.Time
+// does, with their Cmp method if they have one, as *big.Rat does, and with ==
+// otherwise.
+func Equal[T comparable](a, b Nullable[T]) bool {
+	av, aok := a.Get()
+	bv, bok := b.Get()
+	if !aok || !bok {
+		return aok == bok
+	}
+	switch v := any(av).(type) {
+	case interface{ Equal(T) bool }:
+		return v.Equal(bv)
+	case interface{ Cmp(T) int }:
+		return v.Cmp(bv) == 0
+	}
+	return av == bv
+}
+
+// IsValid reports whether n is not null
+func (n String) IsValid() bool {
+	return n.Valid
+}
+
+// IsValid reports whether n is not null
+func (n Int64) IsValid() bool {
+	return n.Valid
+}
+
+// IsValid reports whether n is not null
+func (n Int32) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Int32 and whether n is valid
+func (n Int32) Get() (int32, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Int32, true
+}
+
+// IsValid reports whether n is not null
+func (n Int16) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Int16 and whether n is valid
+func (n Int16) Get() (int16, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Int16, true
+}
+
+// IsValid reports whether n is not null
+func (n Int8) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Int8 and whether n is valid
+func (n Int8) Get() (int8, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Int8, true
+}
+
+// IsValid reports whether n is not null
+func (n Uint64) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Uint64 and whether n is valid
+func (n Uint64) Get() (uint64, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Uint64, true
+}
+
+// IsValid reports whether n is not null
+func (n Uint32) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Uint32 and whether n is valid
+func (n Uint32) Get() (uint32, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Uint32, true
+}
+
+// IsValid reports whether n is not null
+func (n Uint16) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Uint16 and whether n is valid
+func (n Uint16) Get() (uint16, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Uint16, true
+}
+
+// IsValid reports whether n is not null
+func (n Uint8) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Uint8 and whether n is valid
+func (n Uint8) Get() (uint8, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Uint8, true
+}
+
+// IsValid reports whether n is not null
+func (n Float64) IsValid() bool {
+	return n.Valid
+}
+
+// IsValid reports whether n is not null
+func (n Float32) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Float32 and whether n is valid
+func (n Float32) Get() (float32, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Float32, true
+}
+
+// IsValid reports whether n is not null
+func (n Bool) IsValid() bool {
+	return n.Valid
+}
+
+// IsValid reports whether n is not null
+func (n Time) IsValid() bool {
+	return n.Valid
+}
+
+// IsValid reports whether n is not null
+func (n FormattedTime[F]) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Time and whether n is valid
+func (n FormattedTime[F]) Get() (time.Time, bool) {
+	if !n.Valid {
+		return time.Time{}, false
+	}
+	return n.Time, true
+}
+
+// IsValid reports whether n is not null
+func (n Date) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns the time at midnight UTC on n, and whether n is valid
+func (n Date) Get() (time.Time, bool) {
+	if !n.Valid {
+		return time.Time{}, false
+	}
+	return time.Date(n.Year, n.Month, n.Day, 0, 0, 0, 0, time.UTC), true
+}
+
+// IsValid reports whether n is not null
+func (n TimeOfDay) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns the duration since midnight of n, and whether n is valid
+func (n TimeOfDay) Get() (time.Duration, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.sinceMidnight(), true
+}
+
+// IsValid reports whether n is not null
+func (n Duration) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Duration and whether n is valid
+func (n Duration) Get() (time.Duration, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	return n.Duration, true
+}
+
+// IsValid reports whether n is not null
+func (n UUID) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.UUID and whether n is valid
+func (n UUID) Get() ([16]byte, bool) {
+	if !n.Valid {
+		return [16]byte{}, false
+	}
+	return n.UUID, true
+}
+
+// IsValid reports whether n is not null
+func (n Decimal) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n as a new exact big.Rat, and whether n is valid.
+// The big.Rat of a null Decimal is nil.
+func (n Decimal) Get() (*big.Rat, bool) {
+	if !n.Valid {
+		return nil, false
+	}
+	r := new(big.Rat).SetInt(n.int())
+	if n.scale > 0 {
+		r.Quo(r, new(big.Rat).SetInt(pow10(n.scale)))
+	} else if n.scale < 0 {
+		r.Mul(r, new(big.Rat).SetInt(pow10(-n.scale)))
+	}
+	return r, true
+}
+
+// IsValid reports whether n is not null
+func (n Bytes) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Bytes and whether n is valid
+func (n Bytes) Get() ([]byte, bool) {
+	if !n.Valid {
+		return nil, false
+	}
+	return n.Bytes, true
+}
+
+// IsValid reports whether n is not null
+func (n BytesAs[E]) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.Bytes and whether n is valid
+func (n BytesAs[E]) Get() ([]byte, bool) {
+	return Bytes(n).Get()
+}
+
+// IsValid reports whether n is not null, meaning not empty
+func (n RawJSON) IsValid() bool {
+	return !n.IsNull()
+}
+
+// Get returns n as a json.RawMessage, and whether n is valid
+func (n RawJSON) Get() (json.RawMessage, bool) {
+	if n.IsNull() {
+		return nil, false
+	}
+	return json.RawMessage(n), true
+}
+
+// IsValid reports whether n is not null
+func (n Null[T]) IsValid() bool {
+	return n.Valid
+}
+
+// IsValid reports whether o is not null
+func (o Optional[T]) IsValid() bool {
+	return o.Valid
+}
+
+// Get returns o.V and whether o is valid
+func (o Optional[T]) Get() (T, bool) {
+	if !o.Valid {
+		var zero T
+		return zero, false
+	}
+	return o.V, true
+}
+
+// IsValid reports whether n is not null
+func (n JSON[T]) IsValid() bool {
+	return n.Valid
+}
+
+// Get returns n.V and whether n is valid
+func (n JSON[T]) Get() (T, bool) {
+	if !n.Valid {
+		var zero T
+		return zero, false
+	}
+	return n.V, true
+}
@@ -0,0 +1,10 @@
+module github.com/ladydascalie/nullable/nullbson
+
+go 1.22
+
+require (
+	github.com/ladydascalie/nullable v0.0.0
+	go.mongodb.org/mongo-driver v1.17.6
+)
+
+replace github.com/ladydascalie/nullable => ../
@@ -0,0 +1,338 @@
+// Package nullbson provides BSON codecs for the types of package nullable,
+// so that they are stored in MongoDB as BSON null or as their native BSON
+// value, rather than as {string: "...", valid: true} subdocuments.
+//
+// The codecs live in their own module so that nullable does not depend
+// on the MongoDB driver. Hand a registry built by NewRegistry to the client:
+//
+//	opts := options.Client().ApplyURI(uri).SetRegistry(nullbson.NewRegistry())
+//
+// Values are mapped as follows:
+//
+//	String, RawJSON, TimeOfDay      string
+//	Int64, Int32, Int16, Int8       int32 or int64, as for the Go integer types
+//	Uint64, Uint32, Uint16, Uint8   int32 or int64, as for the Go integer types
+//	Float64, Float32                double
+//	Bool                            boolean
+//	Time, FormattedTime             datetime, with millisecond precision
+//	Date                            datetime at midnight UTC
+//	Duration                        int64 nanoseconds, as for time.Duration
+//	UUID                            binary, subtype 4
+//	Decimal                         decimal128
+//	Bytes, BytesAs                  binary, subtype 0
+//	Null, Optional, JSON            the BSON value of V
+//
+// Invalid values are always encoded as BSON null, and both BSON null
+// and undefined decode as invalid values.
+package nullbson
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ladydascalie/nullable"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewRegistry returns the default BSON registry, with the codecs of every
+// concrete type of package nullable registered on it.
+func NewRegistry() *bsoncodec.Registry {
+	reg := bson.NewRegistry()
+	Register(reg)
+	return reg
+}
+
+// Register registers the codecs of every concrete type of package nullable on reg.
+// Generic types must be registered once per instantiation, with RegisterNull,
+// RegisterOptional, RegisterJSON, RegisterFormattedTime and RegisterBytesAs,
+// except for the instantiations with the formats of package nullable.
+func Register(reg *bsoncodec.Registry) {
+	register(reg,
+		func(n nullable.String) (string, bool, error) { return n.String, n.Valid, nil },
+		func(n *nullable.String, v string, ok bool) error {
+			*n = nullable.String{String: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Int64) (int64, bool, error) { return n.Int64, n.Valid, nil },
+		func(n *nullable.Int64, v int64, ok bool) error {
+			*n = nullable.Int64{Int64: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Int32) (int32, bool, error) { return n.Int32, n.Valid, nil },
+		func(n *nullable.Int32, v int32, ok bool) error {
+			*n = nullable.Int32{Int32: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Int16) (int16, bool, error) { return n.Int16, n.Valid, nil },
+		func(n *nullable.Int16, v int16, ok bool) error {
+			*n = nullable.Int16{Int16: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Int8) (int8, bool, error) { return n.Int8, n.Valid, nil },
+		func(n *nullable.Int8, v int8, ok bool) error {
+			*n = nullable.Int8{Int8: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Uint64) (uint64, bool, error) { return n.Uint64, n.Valid, nil },
+		func(n *nullable.Uint64, v uint64, ok bool) error {
+			*n = nullable.Uint64{Uint64: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Uint32) (uint32, bool, error) { return n.Uint32, n.Valid, nil },
+		func(n *nullable.Uint32, v uint32, ok bool) error {
+			*n = nullable.Uint32{Uint32: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Uint16) (uint16, bool, error) { return n.Uint16, n.Valid, nil },
+		func(n *nullable.Uint16, v uint16, ok bool) error {
+			*n = nullable.Uint16{Uint16: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Uint8) (uint8, bool, error) { return n.Uint8, n.Valid, nil },
+		func(n *nullable.Uint8, v uint8, ok bool) error {
+			*n = nullable.Uint8{Uint8: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Float64) (float64, bool, error) { return n.Float64, n.Valid, nil },
+		func(n *nullable.Float64, v float64, ok bool) error {
+			*n = nullable.Float64{Float64: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Float32) (float32, bool, error) { return n.Float32, n.Valid, nil },
+		func(n *nullable.Float32, v float32, ok bool) error {
+			*n = nullable.Float32{Float32: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Bool) (bool, bool, error) { return n.Bool, n.Valid, nil },
+		func(n *nullable.Bool, v bool, ok bool) error {
+			*n = nullable.Bool{Bool: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.Time) (time.Time, bool, error) { return n.Time, n.Valid, nil },
+		func(n *nullable.Time, v time.Time, ok bool) error {
+			*n = nullable.Time{Time: v, Valid: ok}
+			return nil
+		})
+	RegisterFormattedTime[nullable.DefaultTimeFormat](reg)
+	register(reg,
+		func(n nullable.Date) (time.Time, bool, error) { return n.In(time.UTC).Time, n.Valid, nil },
+		func(n *nullable.Date, v time.Time, ok bool) error {
+			*n = nullable.Time{Time: v, Valid: ok}.DateIn(time.UTC)
+			return nil
+		})
+	register(reg,
+		func(n nullable.TimeOfDay) (string, bool, error) {
+			b, err := n.MarshalText()
+			return string(b), n.Valid, err
+		},
+		func(n *nullable.TimeOfDay, v string, ok bool) error {
+			if !ok {
+				*n = nullable.TimeOfDay{}
+				return nil
+			}
+			return n.UnmarshalText([]byte(v))
+		})
+	register(reg,
+		func(n nullable.Duration) (time.Duration, bool, error) { return n.Duration, n.Valid, nil },
+		func(n *nullable.Duration, v time.Duration, ok bool) error {
+			*n = nullable.Duration{Duration: v, Valid: ok}
+			return nil
+		})
+	register(reg,
+		func(n nullable.UUID) (primitive.Binary, bool, error) {
+			return primitive.Binary{Subtype: bson.TypeBinaryUUID, Data: n.UUID[:]}, n.Valid, nil
+		},
+		func(n *nullable.UUID, v primitive.Binary, ok bool) error {
+			*n = nullable.UUID{}
+			if !ok {
+				return nil
+			}
+			if (v.Subtype != bson.TypeBinaryUUID && v.Subtype != bson.TypeBinaryUUIDOld) || len(v.Data) != len(n.UUID) {
+				return fmt.Errorf("nullbson: cannot decode binary subtype %#x of length %d into UUID", v.Subtype, len(v.Data))
+			}
+			copy(n.UUID[:], v.Data)
+			n.Valid = true
+			return nil
+		})
+	register(reg,
+		func(n nullable.Decimal) (primitive.Decimal128, bool, error) {
+			if !n.Valid {
+				return primitive.Decimal128{}, false, nil
+			}
+			d, err := primitive.ParseDecimal128(n.String())
+			return d, true, err
+		},
+		func(n *nullable.Decimal, v primitive.Decimal128, ok bool) error {
+			if !ok {
+				*n = nullable.Decimal{}
+				return nil
+			}
+			d, err := nullable.ParseDecimal(v.String())
+			if err != nil {
+				return err
+			}
+			*n = d
+			return nil
+		})
+	register(reg,
+		func(n nullable.Bytes) ([]byte, bool, error) {
+			if n.Bytes == nil {
+				// A nil slice would be written as BSON null.
+				return []byte{}, n.Valid, nil
+			}
+			return n.Bytes, n.Valid, nil
+		},
+		func(n *nullable.Bytes, v []byte, ok bool) error {
+			*n = nullable.Bytes{Bytes: v, Valid: ok}
+			return nil
+		})
+	RegisterBytesAs[nullable.Base64Encoding](reg)
+	RegisterBytesAs[nullable.Base64URLEncoding](reg)
+	RegisterBytesAs[nullable.HexEncoding](reg)
+	register(reg,
+		func(n nullable.RawJSON) (string, bool, error) { return string(n), !n.IsNull(), nil },
+		func(n *nullable.RawJSON, v string, ok bool) error {
+			if !ok {
+				*n = nil
+				return nil
+			}
+			return n.Scan(v)
+		})
+}
+
+// RegisterNull registers the codec of Null[T] on reg.
+// V is encoded with the codec reg holds for T.
+func RegisterNull[T any](reg *bsoncodec.Registry) {
+	register(reg,
+		func(n nullable.Null[T]) (T, bool, error) { return n.V, n.Valid, nil },
+		func(n *nullable.Null[T], v T, ok bool) error {
+			*n = nullable.Null[T]{V: v, Valid: ok}
+			return nil
+		})
+}
+
+// RegisterOptional registers the codec of Optional[T] on reg.
+// V is encoded with the codec reg holds for T, and decoded values,
+// even BSON null, are marked as Present.
+func RegisterOptional[T any](reg *bsoncodec.Registry) {
+	register(reg,
+		func(o nullable.Optional[T]) (T, bool, error) { return o.V, o.Valid, nil },
+		func(o *nullable.Optional[T], v T, ok bool) error {
+			*o = nullable.Optional[T]{V: v, Valid: ok, Present: true}
+			return nil
+		})
+}
+
+// RegisterJSON registers the codec of JSON[T] on reg.
+// V is stored as its native BSON value, typically an embedded document,
+// rather than as a JSON string.
+func RegisterJSON[T any](reg *bsoncodec.Registry) {
+	register(reg,
+		func(n nullable.JSON[T]) (T, bool, error) { return n.V, n.Valid, nil },
+		func(n *nullable.JSON[T], v T, ok bool) error {
+			*n = nullable.JSON[T]{V: v, Valid: ok}
+			return nil
+		})
+}
+
+// RegisterFormattedTime registers the codec of FormattedTime[F] on reg.
+// Like Time, it is stored as a BSON datetime, so F plays no part.
+func RegisterFormattedTime[F nullable.TimeFormat](reg *bsoncodec.Registry) {
+	register(reg,
+		func(n nullable.FormattedTime[F]) (time.Time, bool, error) { return n.Time, n.Valid, nil },
+		func(n *nullable.FormattedTime[F], v time.Time, ok bool) error {
+			*n = nullable.FormattedTime[F]{Time: v, Valid: ok}
+			return nil
+		})
+}
+
+// RegisterBytesAs registers the codec of BytesAs[E] on reg.
+// Like Bytes, it is stored as BSON binary, so E plays no part.
+func RegisterBytesAs[E nullable.BinaryEncoding](reg *bsoncodec.Registry) {
+	register(reg,
+		func(n nullable.BytesAs[E]) ([]byte, bool, error) {
+			if n.Bytes == nil {
+				// A nil slice would be written as BSON null.
+				return []byte{}, n.Valid, nil
+			}
+			return n.Bytes, n.Valid, nil
+		},
+		func(n *nullable.BytesAs[E], v []byte, ok bool) error {
+			*n = nullable.BytesAs[E]{Bytes: v, Valid: ok}
+			return nil
+		})
+}
+
+// register registers a codec for N on reg, which converts N to and from T
+// and delegates the encoding of valid values to the codec reg holds for T.
+// get reports whether n is valid, and set is called with ok set to false
+// when BSON null or undefined is decoded.
+func register[N, T any](reg *bsoncodec.Registry, get func(n N) (T, bool, error), set func(n *N, v T, ok bool) error) {
+	typ := reflect.TypeOf((*N)(nil)).Elem()
+	name := typ.Name()
+
+	reg.RegisterTypeEncoder(typ, bsoncodec.ValueEncoderFunc(func(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+		if !val.IsValid() || val.Type() != typ {
+			return bsoncodec.ValueEncoderError{Name: name + "EncodeValue", Types: []reflect.Type{typ}, Received: val}
+		}
+		v, ok, err := get(val.Interface().(N))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return vw.WriteNull()
+		}
+		rv := reflect.ValueOf(&v).Elem()
+		enc, err := ec.LookupEncoder(rv.Type())
+		if err != nil {
+			return err
+		}
+		return enc.EncodeValue(ec, vw, rv)
+	}))
+
+	reg.RegisterTypeDecoder(typ, bsoncodec.ValueDecoderFunc(func(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+		if !val.CanSet() || val.Type() != typ {
+			return bsoncodec.ValueDecoderError{Name: name + "DecodeValue", Types: []reflect.Type{typ}, Received: val}
+		}
+		n := val.Addr().Interface().(*N)
+		var v T
+		switch vr.Type() {
+		case bsontype.Null:
+			if err := vr.ReadNull(); err != nil {
+				return err
+			}
+			return set(n, v, false)
+		case bsontype.Undefined:
+			if err := vr.ReadUndefined(); err != nil {
+				return err
+			}
+			return set(n, v, false)
+		}
+		rv := reflect.ValueOf(&v).Elem()
+		dec, err := dc.LookupDecoder(rv.Type())
+		if err != nil {
+			return err
+		}
+		if err := dec.DecodeValue(dc, vr, rv); err != nil {
+			return err
+		}
+		return set(n, v, true)
+	}))
+}
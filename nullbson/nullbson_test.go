@@ -0,0 +1,224 @@
+package nullbson
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ladydascalie/nullable"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type record struct {
+	String    nullable.String                                    `bson:"string"`
+	Int64     nullable.Int64                                     `bson:"int64"`
+	Int32     nullable.Int32                                     `bson:"int32"`
+	Int16     nullable.Int16                                     `bson:"int16"`
+	Int8      nullable.Int8                                      `bson:"int8"`
+	Uint64    nullable.Uint64                                    `bson:"uint64"`
+	Uint32    nullable.Uint32                                    `bson:"uint32"`
+	Uint16    nullable.Uint16                                    `bson:"uint16"`
+	Uint8     nullable.Uint8                                     `bson:"uint8"`
+	Float64   nullable.Float64                                   `bson:"float64"`
+	Float32   nullable.Float32                                   `bson:"float32"`
+	Bool      nullable.Bool                                      `bson:"bool"`
+	Time      nullable.Time                                      `bson:"time"`
+	Formatted nullable.FormattedTime[nullable.DefaultTimeFormat] `bson:"formatted"`
+	Date      nullable.Date                                      `bson:"date"`
+	TimeOfDay nullable.TimeOfDay                                 `bson:"time_of_day"`
+	Duration  nullable.Duration                                  `bson:"duration"`
+	UUID      nullable.UUID                                      `bson:"uuid"`
+	Decimal   nullable.Decimal                                   `bson:"decimal"`
+	Bytes     nullable.Bytes                                     `bson:"bytes"`
+	HexBytes  nullable.BytesAs[nullable.HexEncoding]             `bson:"hex_bytes"`
+	RawJSON   nullable.RawJSON                                   `bson:"raw_json"`
+	Tags      nullable.Null[[]string]                            `bson:"tags"`
+	Override  nullable.Optional[string]                          `bson:"override"`
+	Meta      nullable.JSON[map[string]int32]                    `bson:"meta"`
+}
+
+func newRegistry() *bsoncodec.Registry {
+	reg := NewRegistry()
+	RegisterNull[[]string](reg)
+	RegisterOptional[string](reg)
+	RegisterJSON[map[string]int32](reg)
+	return reg
+}
+
+func TestBSON(t *testing.T) {
+	stamp := time.Date(2017, 11, 24, 10, 30, 0, 123000000, time.UTC)
+	decimal, _ := nullable.ParseDecimal("-12.3400")
+	id, _ := nullable.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	valid := record{
+		String:    nullable.String{String: "hello", Valid: true},
+		Int64:     nullable.Int64{Int64: 1 << 40, Valid: true},
+		Int32:     nullable.Int32{Int32: -32, Valid: true},
+		Int16:     nullable.Int16{Int16: 16, Valid: true},
+		Int8:      nullable.Int8{Int8: -8, Valid: true},
+		Uint64:    nullable.Uint64{Uint64: 64, Valid: true},
+		Uint32:    nullable.Uint32{Uint32: 32, Valid: true},
+		Uint16:    nullable.Uint16{Uint16: 16, Valid: true},
+		Uint8:     nullable.Uint8{Uint8: 8, Valid: true},
+		Float64:   nullable.Float64{Float64: 1.5, Valid: true},
+		Float32:   nullable.Float32{Float32: 0.25, Valid: true},
+		Bool:      nullable.Bool{Bool: false, Valid: true},
+		Time:      nullable.Time{Time: stamp, Valid: true},
+		Formatted: nullable.FormattedTime[nullable.DefaultTimeFormat]{Time: stamp, Valid: true},
+		Date:      nullable.Date{Year: 2017, Month: time.November, Day: 24, Valid: true},
+		TimeOfDay: nullable.TimeOfDay{Hour: 10, Minute: 30, Second: 5, Valid: true},
+		Duration:  nullable.Duration{Duration: 90 * time.Second, Valid: true},
+		UUID:      id,
+		Decimal:   decimal,
+		Bytes:     nullable.Bytes{Bytes: []byte{}, Valid: true},
+		HexBytes:  nullable.BytesAs[nullable.HexEncoding]{Bytes: []byte{1}, Valid: true},
+		RawJSON:   nullable.RawJSON(`{"a":1}`),
+		Tags:      nullable.Null[[]string]{V: []string{"a", "b"}, Valid: true},
+		Override:  nullable.Optional[string]{V: "x", Valid: true, Present: true},
+		Meta:      nullable.JSON[map[string]int32]{V: map[string]int32{"n": 1}, Valid: true},
+	}
+
+	var tests = []struct {
+		name   string
+		input  record
+		want   record
+		native bson.M
+	}{
+		{
+			name:  "valid",
+			input: valid,
+			want:  valid,
+			native: bson.M{
+				"string":      "hello",
+				"int64":       int64(1 << 40),
+				"int32":       int32(-32),
+				"int16":       int32(16),
+				"int8":        int32(-8),
+				"uint64":      int64(64),
+				"uint32":      int64(32),
+				"uint16":      int32(16),
+				"uint8":       int32(8),
+				"float64":     1.5,
+				"float32":     0.25,
+				"bool":        false,
+				"time":        primitive.NewDateTimeFromTime(stamp),
+				"formatted":   primitive.NewDateTimeFromTime(stamp),
+				"date":        primitive.NewDateTimeFromTime(time.Date(2017, 11, 24, 0, 0, 0, 0, time.UTC)),
+				"time_of_day": "10:30:05",
+				"duration":    int64(90 * time.Second),
+				"uuid":        primitive.Binary{Subtype: bson.TypeBinaryUUID, Data: id.UUID[:]},
+				"decimal":     mustDecimal128(t, "-12.3400"),
+				"bytes":       primitive.Binary{Subtype: bson.TypeBinaryGeneric, Data: []byte{}},
+				"hex_bytes":   primitive.Binary{Subtype: bson.TypeBinaryGeneric, Data: []byte{1}},
+				"raw_json":    `{"a":1}`,
+				"tags":        bson.A{"a", "b"},
+				"override":    "x",
+				"meta":        bson.M{"n": int32(1)},
+			},
+		},
+		{
+			name:  "null",
+			input: record{},
+			want:  record{Override: nullable.Optional[string]{Present: true}},
+			native: bson.M{
+				"string": nil, "int64": nil, "int32": nil, "int16": nil, "int8": nil,
+				"uint64": nil, "uint32": nil, "uint16": nil, "uint8": nil,
+				"float64": nil, "float32": nil, "bool": nil,
+				"time": nil, "formatted": nil, "date": nil, "time_of_day": nil, "duration": nil,
+				"uuid": nil, "decimal": nil, "bytes": nil, "hex_bytes": nil, "raw_json": nil,
+				"tags": nil, "override": nil, "meta": nil,
+			},
+		},
+	}
+
+	reg := newRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bson.MarshalWithRegistry(reg, tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var native bson.M
+			if err := bson.Unmarshal(b, &native); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(native, tt.native) {
+				t.Fatalf("\nexp: %#v\ngot: %#v", tt.native, native)
+			}
+
+			var got record
+			if err := bson.UnmarshalWithRegistry(reg, b, &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("\nexp: %+v\ngot: %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBSONDecode(t *testing.T) {
+	reg := newRegistry()
+
+	t.Run("numeric conversions", func(t *testing.T) {
+		b, _ := bson.Marshal(bson.M{"int64": int32(7), "float64": int64(2), "uint8": int64(255)})
+		var got record
+		if err := bson.UnmarshalWithRegistry(reg, b, &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Int64 != (nullable.Int64{Int64: 7, Valid: true}) ||
+			got.Float64 != (nullable.Float64{Float64: 2, Valid: true}) ||
+			got.Uint8 != (nullable.Uint8{Uint8: 255, Valid: true}) {
+			t.Fatalf("unexpected values: %+v", got)
+		}
+	})
+
+	t.Run("absent fields", func(t *testing.T) {
+		b, _ := bson.Marshal(bson.M{})
+		var got record
+		if err := bson.UnmarshalWithRegistry(reg, b, &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Override.Present {
+			t.Fatal("expected absent Optional not to be Present")
+		}
+	})
+
+	var errTests = []struct {
+		name string
+		doc  bson.M
+	}{
+		{name: "overflow", doc: bson.M{"int8": int32(300)}},
+		{name: "wrong type", doc: bson.M{"bool": "true"}},
+		{name: "uuid length", doc: bson.M{"uuid": primitive.Binary{Subtype: bson.TypeBinaryUUID, Data: []byte{1, 2}}}},
+		{name: "invalid json", doc: bson.M{"raw_json": "{"}},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := bson.Marshal(tt.doc)
+			var got record
+			if err := bson.UnmarshalWithRegistry(reg, b, &got); err == nil {
+				t.Fatalf("expected an error, got %+v", got)
+			}
+		})
+	}
+
+	t.Run("uint64 overflow", func(t *testing.T) {
+		_, err := bson.MarshalWithRegistry(reg, record{Uint64: nullable.Uint64{Uint64: 1 << 63, Valid: true}})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func mustDecimal128(t *testing.T, s string) primitive.Decimal128 {
+	t.Helper()
+	d, err := primitive.ParseDecimal128(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
@@ -0,0 +1,12 @@
+module github.com/ladydascalie/nullable/nullcbor
+
+go 1.22
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.2
+	github.com/ladydascalie/nullable v0.0.0
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
+
+replace github.com/ladydascalie/nullable => ../
@@ -0,0 +1,75 @@
+// Package nullcbor encodes and decodes the types of package nullable as CBOR
+// with github.com/fxamacker/cbor/v2, so that invalid values are encoded as
+// CBOR null and valid values as their native CBOR value, rather than as maps.
+//
+// Valid values are encoded and decoded by the caller's modes, so that all of
+// their options apply, such as the encoding of times or the nesting limits:
+//
+//	b, err := nullcbor.Marshal(em, n)
+//	err = nullcbor.Unmarshal(dm, b, &n)
+//
+// The value encoded is the one returned by Get, such as a time.Time for
+// Date or a time.Duration for TimeOfDay, except for Decimal, which CBOR
+// has no encoding of, and which is encoded as a text string instead.
+//
+// fxamacker/cbor gives no access to the mode in use to the cbor.Marshaler
+// and cbor.Unmarshaler interfaces, so the types of package nullable do not
+// implement them. Struct fields are best declared as pointers, which the
+// modes encode as null when nil, and converted with Ptr.
+package nullcbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ladydascalie/nullable"
+)
+
+// Marshal encodes n with em, as CBOR null if n is null
+func Marshal[T any](em cbor.EncMode, n nullable.Nullable[T]) ([]byte, error) {
+	if d, ok := asDecimal(n); ok && d.Valid {
+		return em.Marshal(d.String())
+	}
+	v, ok := n.Get()
+	if !ok {
+		return em.Marshal(nil)
+	}
+	return em.Marshal(v)
+}
+
+// Unmarshal decodes data into n with dm.
+// CBOR null and undefined are both decoded as null.
+// Optional and RawJSON, which do not implement nullable.Accessor, are not supported.
+func Unmarshal[T any](dm cbor.DecMode, data []byte, n nullable.Accessor[T]) error {
+	if d, ok := any(n).(*nullable.Decimal); ok {
+		var s *string
+		if err := dm.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s == nil {
+			d.Clear()
+			return nil
+		}
+		return d.UnmarshalText([]byte(*s))
+	}
+
+	var v *T
+	if err := dm.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v == nil {
+		n.Clear()
+		return nil
+	}
+	n.Set(*v)
+	return nil
+}
+
+// asDecimal returns n as a Decimal, if it is one
+func asDecimal(n any) (nullable.Decimal, bool) {
+	switch d := n.(type) {
+	case nullable.Decimal:
+		return d, true
+	case *nullable.Decimal:
+		return *d, true
+	}
+	return nullable.Decimal{}, false
+}
@@ -0,0 +1,171 @@
+package nullcbor
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ladydascalie/nullable"
+)
+
+func TestCBOR(t *testing.T) {
+	stamp := time.Date(2017, 11, 24, 10, 30, 0, 123456789, time.FixedZone("CET", 3600))
+	id, _ := nullable.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	em, err := cbor.EncOptions{Time: cbor.TimeRFC3339Nano, TimeTag: cbor.EncTagRequired}.EncMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm, err := cbor.DecOptions{}.DecMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, em cbor.EncMode, dm cbor.DecMode)
+	}{
+		{name: "String", test: check(&nullable.String{String: "hello", Valid: true}, "hello")},
+		{name: "String null", test: check(&nullable.String{}, nil)},
+		{name: "Int64", test: check(&nullable.Int64{Int64: -1 << 40, Valid: true}, int64(-1<<40))},
+		{name: "Int64 null", test: check(&nullable.Int64{}, nil)},
+		{name: "Int8", test: check(&nullable.Int8{Int8: -8, Valid: true}, int8(-8))},
+		{name: "Uint64", test: check(&nullable.Uint64{Uint64: 1 << 63, Valid: true}, uint64(1<<63))},
+		{name: "Float64", test: check(&nullable.Float64{Float64: 1.5, Valid: true}, 1.5)},
+		{name: "Float32", test: check(&nullable.Float32{Float32: 0.25, Valid: true}, float32(0.25))},
+		{name: "Bool", test: check(&nullable.Bool{Bool: false, Valid: true}, false)},
+		{name: "Time", test: check(&nullable.Time{Time: stamp, Valid: true}, cbor.Tag{Number: 0, Content: "2017-11-24T10:30:00.123456789+01:00"})},
+		{name: "Time null", test: check(&nullable.Time{}, nil)},
+		{name: "Date", test: check(&nullable.Date{Year: 2017, Month: time.November, Day: 24, Valid: true}, cbor.Tag{Number: 0, Content: "2017-11-24T00:00:00Z"})},
+		{name: "TimeOfDay", test: check(&nullable.TimeOfDay{Hour: 10, Minute: 30, Valid: true}, int64(10*time.Hour+30*time.Minute))},
+		{name: "Duration", test: check(&nullable.Duration{Duration: time.Minute, Valid: true}, int64(time.Minute))},
+		{name: "UUID", test: check(&id, id.UUID[:])},
+		{name: "UUID null", test: check(&nullable.UUID{}, nil)},
+		{name: "Decimal", test: check(ptr(nullable.NewDecimal(-123400, 4)), "-12.3400")},
+		{name: "Decimal null", test: check(&nullable.Decimal{}, nil)},
+		{name: "Bytes", test: check(&nullable.Bytes{Bytes: []byte{1, 2}, Valid: true}, []byte{1, 2})},
+		{name: "Bytes null", test: check(&nullable.Bytes{}, nil)},
+		{name: "Null", test: check(&nullable.Null[[]string]{V: []string{"a"}, Valid: true}, []string{"a"})},
+		{name: "Null null", test: check(&nullable.Null[[]string]{}, nil)},
+		{name: "JSON", test: check(&nullable.JSON[map[string]int]{V: map[string]int{"a": 1}, Valid: true}, map[string]int{"a": 1})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { tt.test(t, em, dm) })
+	}
+}
+
+// check returns a test that n is encoded as native, and decoded back from it
+func check[T any, P interface {
+	nullable.Nullable[T]
+	nullable.Accessor[T]
+}](n P, native any) func(t *testing.T, em cbor.EncMode, dm cbor.DecMode) {
+	return func(t *testing.T, em cbor.EncMode, dm cbor.DecMode) {
+		got, err := Marshal[T](em, n)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want, err := em.Marshal(native)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("\nexp: %x\ngot: %x", want, got)
+		}
+
+		// Decode into a valid value, so that null is seen to reset it.
+		back := reflect.New(reflect.TypeOf(n).Elem()).Interface().(P)
+		var v T
+		back.Set(v)
+		if err := Unmarshal[T](dm, want, back); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !equal(back, n) {
+			t.Fatalf("\nexp: %+v\ngot: %+v", n, back)
+		}
+	}
+}
+
+func TestModes(t *testing.T) {
+	type point struct {
+		X int `cbor:"1,keyasint"`
+		Y int `cbor:"2,keyasint"`
+	}
+	n := nullable.Null[point]{V: point{X: 1, Y: 2}, Valid: true}
+	em, _ := cbor.EncOptions{}.EncMode()
+	b, err := Marshal[point](em, n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []byte{0xa2, 0x01, 0x01, 0x02, 0x02}; !bytes.Equal(b, want) {
+		t.Fatalf("keyasint ignored: %x", b)
+	}
+
+	unix, _ := cbor.EncOptions{Time: cbor.TimeUnix}.EncMode()
+	stamp := time.Unix(1511519400, 0)
+	if b, _ := Marshal[time.Time](unix, nullable.Time{Time: stamp, Valid: true}); !bytes.Equal(b, mustMarshal(t, unix, stamp.Unix())) {
+		t.Fatalf("Unix time ignored: %x", b)
+	}
+
+	// The decoding mode limits the nesting of V.
+	nested := []any{[]any{[]any{[]any{[]any{[]any{}}}}}}
+	shallow, _ := cbor.DecOptions{MaxNestedLevels: 4}.DecMode()
+	var deep nullable.Null[[]any]
+	if err := Unmarshal[[]any](shallow, mustMarshal(t, em, nested), &deep); err == nil {
+		t.Fatalf("expected the nesting limit to apply, got %+v", deep)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	dm, _ := cbor.DecOptions{}.DecMode()
+
+	n := nullable.String{String: "a", Valid: true}
+	if err := Unmarshal[string](dm, []byte{0xf7}, &n); err != nil || n.Valid {
+		t.Fatalf("expected undefined to decode as null, got %+v, %v", n, err)
+	}
+
+	errTests := []struct {
+		name string
+		data []byte
+		test func(data []byte) error
+	}{
+		{name: "overflow", data: []byte{0x19, 0x01, 0x2c}, test: func(data []byte) error { return Unmarshal[int8](dm, data, &nullable.Int8{}) }},
+		{name: "decimal", data: []byte{0x63, 'a', 'b', 'c'}, test: func(data []byte) error { return Unmarshal[*big.Rat](dm, data, &nullable.Decimal{}) }},
+		{name: "wrong type", data: []byte{0x64, 't', 'r', 'u', 'e'}, test: func(data []byte) error { return Unmarshal[bool](dm, data, &nullable.Bool{}) }},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.test(tt.data); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+// equal compares decoded values, comparing times with time.Time.Equal
+// as time zones are decoded as fixed offsets.
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case *nullable.Time:
+		b := b.(*nullable.Time)
+		return a.Valid == b.Valid && a.Time.Equal(b.Time)
+	case *nullable.Decimal:
+		return a.Equal(*b.(*nullable.Decimal)) && a.Scale() == b.(*nullable.Decimal).Scale()
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func mustMarshal(t *testing.T, em cbor.EncMode, v any) []byte {
+	t.Helper()
+	b, err := em.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
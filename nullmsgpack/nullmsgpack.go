@@ -0,0 +1,293 @@
+// Package nullmsgpack registers MessagePack codecs for the types of package
+// nullable with github.com/vmihailenco/msgpack/v5, so that invalid values
+// are encoded as nil and valid values as their native MessagePack value,
+// rather than as maps or text.
+//
+// Importing the package registers the concrete types:
+//
+//	import _ "github.com/ladydascalie/nullable/nullmsgpack"
+//
+// Generic types must be registered once per instantiation, with RegisterNull,
+// RegisterOptional, RegisterJSON, RegisterFormattedTime and RegisterBytesAs,
+// except for the instantiations with the formats of package nullable.
+// Types that are not registered, including all of them when this package is
+// not imported, implement encoding.BinaryMarshaler, which msgpack falls back
+// to: they are encoded as bin values holding their MarshalBinary form, which
+// round-trips but is opaque to other MessagePack readers.
+//
+// Values are mapped as follows:
+//
+//	String, Date, TimeOfDay, Decimal, RawJSON    str, using their text encoding
+//	Int64, Int32, Int16, Int8                    int
+//	Uint64, Uint32, Uint16, Uint8                uint
+//	Float64, Float32                             float 64 or float 32
+//	Bool                                         bool
+//	Time, FormattedTime                          timestamp extension
+//	Duration                                     int nanoseconds, as for time.Duration
+//	UUID, Bytes, BytesAs                         bin
+//	Null, Optional, JSON                         the MessagePack value of V
+//
+// Decoding goes through msgpack, so that integers of any width,
+// for instance, decode into Int64.
+package nullmsgpack
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ladydascalie/nullable"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+func init() {
+	register(
+		func(n nullable.String) (string, bool, error) { return n.String, n.Valid, nil },
+		func(n *nullable.String, v string, ok bool) error {
+			*n = nullable.String{String: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Int64) (int64, bool, error) { return n.Int64, n.Valid, nil },
+		func(n *nullable.Int64, v int64, ok bool) error {
+			*n = nullable.Int64{Int64: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Int32) (int32, bool, error) { return n.Int32, n.Valid, nil },
+		func(n *nullable.Int32, v int32, ok bool) error {
+			*n = nullable.Int32{Int32: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Int16) (int16, bool, error) { return n.Int16, n.Valid, nil },
+		func(n *nullable.Int16, v int16, ok bool) error {
+			*n = nullable.Int16{Int16: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Int8) (int8, bool, error) { return n.Int8, n.Valid, nil },
+		func(n *nullable.Int8, v int8, ok bool) error {
+			*n = nullable.Int8{Int8: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Uint64) (uint64, bool, error) { return n.Uint64, n.Valid, nil },
+		func(n *nullable.Uint64, v uint64, ok bool) error {
+			*n = nullable.Uint64{Uint64: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Uint32) (uint32, bool, error) { return n.Uint32, n.Valid, nil },
+		func(n *nullable.Uint32, v uint32, ok bool) error {
+			*n = nullable.Uint32{Uint32: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Uint16) (uint16, bool, error) { return n.Uint16, n.Valid, nil },
+		func(n *nullable.Uint16, v uint16, ok bool) error {
+			*n = nullable.Uint16{Uint16: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Uint8) (uint8, bool, error) { return n.Uint8, n.Valid, nil },
+		func(n *nullable.Uint8, v uint8, ok bool) error {
+			*n = nullable.Uint8{Uint8: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Float64) (float64, bool, error) { return n.Float64, n.Valid, nil },
+		func(n *nullable.Float64, v float64, ok bool) error {
+			*n = nullable.Float64{Float64: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Float32) (float32, bool, error) { return n.Float32, n.Valid, nil },
+		func(n *nullable.Float32, v float32, ok bool) error {
+			*n = nullable.Float32{Float32: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Bool) (bool, bool, error) { return n.Bool, n.Valid, nil },
+		func(n *nullable.Bool, v bool, ok bool) error {
+			*n = nullable.Bool{Bool: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.Time) (time.Time, bool, error) { return n.Time, n.Valid, nil },
+		func(n *nullable.Time, v time.Time, ok bool) error {
+			*n = nullable.Time{Time: v, Valid: ok}
+			return nil
+		})
+	RegisterFormattedTime[nullable.DefaultTimeFormat]()
+	register(
+		func(n nullable.Duration) (time.Duration, bool, error) { return n.Duration, n.Valid, nil },
+		func(n *nullable.Duration, v time.Duration, ok bool) error {
+			*n = nullable.Duration{Duration: v, Valid: ok}
+			return nil
+		})
+	register(
+		func(n nullable.UUID) ([]byte, bool, error) { return n.UUID[:], n.Valid, nil },
+		func(n *nullable.UUID, v []byte, ok bool) error {
+			*n = nullable.UUID{}
+			if !ok {
+				return nil
+			}
+			if len(v) != len(n.UUID) {
+				return fmt.Errorf("nullmsgpack: cannot decode %d bytes into UUID", len(v))
+			}
+			copy(n.UUID[:], v)
+			n.Valid = true
+			return nil
+		})
+	register(
+		func(n nullable.Bytes) ([]byte, bool, error) {
+			if n.Bytes == nil {
+				// A nil slice would be encoded as nil.
+				return []byte{}, n.Valid, nil
+			}
+			return n.Bytes, n.Valid, nil
+		},
+		func(n *nullable.Bytes, v []byte, ok bool) error {
+			*n = nullable.Bytes{Bytes: v, Valid: ok}
+			return nil
+		})
+	RegisterBytesAs[nullable.Base64Encoding]()
+	RegisterBytesAs[nullable.Base64URLEncoding]()
+	RegisterBytesAs[nullable.HexEncoding]()
+	registerText[nullable.Date]()
+	registerText[nullable.TimeOfDay]()
+	registerText[nullable.Decimal]()
+	register(
+		func(n nullable.RawJSON) (string, bool, error) { return string(n), !n.IsNull(), nil },
+		func(n *nullable.RawJSON, v string, ok bool) error {
+			if !ok {
+				*n = nil
+				return nil
+			}
+			return n.Scan(v)
+		})
+}
+
+// RegisterNull registers the codec of Null[T].
+// V is encoded as msgpack encodes any T.
+func RegisterNull[T any]() {
+	register(
+		func(n nullable.Null[T]) (T, bool, error) { return n.V, n.Valid, nil },
+		func(n *nullable.Null[T], v T, ok bool) error {
+			*n = nullable.Null[T]{V: v, Valid: ok}
+			return nil
+		})
+}
+
+// RegisterOptional registers the codec of Optional[T].
+// V is encoded as msgpack encodes any T, and decoded values,
+// even nil, are marked as Present.
+func RegisterOptional[T any]() {
+	register(
+		func(o nullable.Optional[T]) (T, bool, error) { return o.V, o.Valid, nil },
+		func(o *nullable.Optional[T], v T, ok bool) error {
+			*o = nullable.Optional[T]{V: v, Valid: ok, Present: true}
+			return nil
+		})
+}
+
+// RegisterJSON registers the codec of JSON[T].
+// V is encoded as its native MessagePack value, typically a map,
+// rather than as a JSON string.
+func RegisterJSON[T any]() {
+	register(
+		func(n nullable.JSON[T]) (T, bool, error) { return n.V, n.Valid, nil },
+		func(n *nullable.JSON[T], v T, ok bool) error {
+			*n = nullable.JSON[T]{V: v, Valid: ok}
+			return nil
+		})
+}
+
+// RegisterFormattedTime registers the codec of FormattedTime[F].
+// Like Time, it is encoded with the timestamp extension, so F plays no part.
+func RegisterFormattedTime[F nullable.TimeFormat]() {
+	register(
+		func(n nullable.FormattedTime[F]) (time.Time, bool, error) { return n.Time, n.Valid, nil },
+		func(n *nullable.FormattedTime[F], v time.Time, ok bool) error {
+			*n = nullable.FormattedTime[F]{Time: v, Valid: ok}
+			return nil
+		})
+}
+
+// RegisterBytesAs registers the codec of BytesAs[E].
+// Like Bytes, it is encoded as bin, so E plays no part.
+func RegisterBytesAs[E nullable.BinaryEncoding]() {
+	register(
+		func(n nullable.BytesAs[E]) ([]byte, bool, error) {
+			if n.Bytes == nil {
+				// A nil slice would be encoded as nil.
+				return []byte{}, n.Valid, nil
+			}
+			return n.Bytes, n.Valid, nil
+		},
+		func(n *nullable.BytesAs[E], v []byte, ok bool) error {
+			*n = nullable.BytesAs[E]{Bytes: v, Valid: ok}
+			return nil
+		})
+}
+
+// textCodec is implemented by the pointers to the types encoded as text
+type textCodec[N any] interface {
+	*N
+	MarshalText() ([]byte, error)
+	UnmarshalText([]byte) error
+}
+
+// registerText registers a codec for N which encodes it using its text
+// encoding, relying on an empty text being decoded as null.
+func registerText[N any, P textCodec[N]]() {
+	register(
+		func(n N) (string, bool, error) {
+			b, err := P(&n).MarshalText()
+			return string(b), len(b) > 0, err
+		},
+		func(n *N, v string, ok bool) error {
+			if !ok {
+				v = ""
+			}
+			return P(n).UnmarshalText([]byte(v))
+		})
+}
+
+// register registers a codec for N, which converts N to and from T and
+// leaves the encoding of valid values to the codec msgpack uses for T.
+// get reports whether n is valid, and set is called with ok set to false
+// when nil is decoded.
+func register[N, T any](get func(n N) (T, bool, error), set func(n *N, v T, ok bool) error) {
+	var zero N
+	msgpack.Register(zero,
+		func(e *msgpack.Encoder, val reflect.Value) error {
+			v, ok, err := get(val.Interface().(N))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return e.EncodeNil()
+			}
+			return e.Encode(v)
+		},
+		func(d *msgpack.Decoder, val reflect.Value) error {
+			var v T
+			code, err := d.PeekCode()
+			if err != nil {
+				return err
+			}
+			if code == msgpcode.Nil {
+				if err := d.DecodeNil(); err != nil {
+					return err
+				}
+				return set(val.Addr().Interface().(*N), v, false)
+			}
+			if err := d.Decode(&v); err != nil {
+				return err
+			}
+			return set(val.Addr().Interface().(*N), v, true)
+		})
+}
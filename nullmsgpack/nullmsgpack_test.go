@@ -0,0 +1,204 @@
+package nullmsgpack
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ladydascalie/nullable"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func init() {
+	RegisterNull[[]string]()
+	RegisterOptional[string]()
+	RegisterJSON[map[string]int]()
+}
+
+func TestMsgpack(t *testing.T) {
+	stamp := time.Date(2017, 11, 24, 10, 30, 0, 123456789, time.UTC)
+	id, _ := nullable.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	tests := []struct {
+		name   string
+		value  any // a pointer to the nullable value
+		native any // the value it must be encoded as
+	}{
+		{name: "String", value: &nullable.String{String: "hello", Valid: true}, native: "hello"},
+		{name: "String null", value: &nullable.String{}, native: nil},
+		{name: "Int64", value: &nullable.Int64{Int64: -1 << 40, Valid: true}, native: int64(-1 << 40)},
+		{name: "Int64 null", value: &nullable.Int64{}, native: nil},
+		{name: "Int32", value: &nullable.Int32{Int32: -32, Valid: true}, native: int32(-32)},
+		{name: "Int16", value: &nullable.Int16{Int16: 16, Valid: true}, native: int16(16)},
+		{name: "Int8", value: &nullable.Int8{Int8: -8, Valid: true}, native: int8(-8)},
+		{name: "Uint64", value: &nullable.Uint64{Uint64: 1 << 63, Valid: true}, native: uint64(1 << 63)},
+		{name: "Uint32", value: &nullable.Uint32{Uint32: 32, Valid: true}, native: uint32(32)},
+		{name: "Uint16", value: &nullable.Uint16{Uint16: 16, Valid: true}, native: uint16(16)},
+		{name: "Uint8", value: &nullable.Uint8{Uint8: 8, Valid: true}, native: uint8(8)},
+		{name: "Uint8 null", value: &nullable.Uint8{}, native: nil},
+		{name: "Float64", value: &nullable.Float64{Float64: 1.5, Valid: true}, native: 1.5},
+		{name: "Float64 null", value: &nullable.Float64{}, native: nil},
+		{name: "Float32", value: &nullable.Float32{Float32: 0.25, Valid: true}, native: float32(0.25)},
+		{name: "Bool", value: &nullable.Bool{Bool: false, Valid: true}, native: false},
+		{name: "Bool null", value: &nullable.Bool{}, native: nil},
+		{name: "Time", value: &nullable.Time{Time: stamp, Valid: true}, native: stamp},
+		{name: "Time null", value: &nullable.Time{}, native: nil},
+		{
+			name:   "FormattedTime",
+			value:  &nullable.FormattedTime[nullable.DefaultTimeFormat]{Time: stamp, Valid: true},
+			native: stamp,
+		},
+		{name: "Date", value: &nullable.Date{Year: 2017, Month: time.November, Day: 24, Valid: true}, native: "2017-11-24"},
+		{name: "Date null", value: &nullable.Date{}, native: nil},
+		{name: "TimeOfDay", value: &nullable.TimeOfDay{Hour: 10, Minute: 30, Valid: true}, native: "10:30:00"},
+		{name: "Duration", value: &nullable.Duration{Duration: time.Minute, Valid: true}, native: time.Minute},
+		{name: "UUID", value: &id, native: id.UUID[:]},
+		{name: "UUID null", value: &nullable.UUID{}, native: nil},
+		{name: "Decimal", value: ptr(nullable.NewDecimal(-123400, 4)), native: "-12.3400"},
+		{name: "Decimal null", value: &nullable.Decimal{}, native: nil},
+		{name: "Bytes", value: &nullable.Bytes{Bytes: []byte{1, 2}, Valid: true}, native: []byte{1, 2}},
+		{name: "Bytes empty", value: &nullable.Bytes{Bytes: []byte{}, Valid: true}, native: []byte{}},
+		{name: "Bytes null", value: &nullable.Bytes{}, native: nil},
+		{name: "BytesAs", value: &nullable.BytesAs[nullable.HexEncoding]{Bytes: []byte{1, 2}, Valid: true}, native: []byte{1, 2}},
+		{name: "RawJSON", value: ptr(nullable.RawJSON(`{"a":1}`)), native: `{"a":1}`},
+		{name: "RawJSON null", value: ptr(nullable.RawJSON(nil)), native: nil},
+		{name: "Null", value: &nullable.Null[[]string]{V: []string{"a"}, Valid: true}, native: []string{"a"}},
+		{name: "Null null", value: &nullable.Null[[]string]{}, native: nil},
+		{name: "Optional", value: &nullable.Optional[string]{V: "a", Valid: true, Present: true}, native: "a"},
+		{name: "Optional null", value: &nullable.Optional[string]{Present: true}, native: nil},
+		{name: "JSON", value: &nullable.JSON[map[string]int]{V: map[string]int{"a": 1}, Valid: true}, native: map[string]int{"a": 1}},
+		{name: "JSON null", value: &nullable.JSON[map[string]int]{}, native: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := msgpack.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want, err := msgpack.Marshal(tt.native)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("\nexp: %x\ngot: %x", want, got)
+			}
+
+			decoded := reflect.New(reflect.TypeOf(tt.value).Elem())
+			if err := msgpack.Unmarshal(want, decoded.Interface()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equal(decoded.Interface(), tt.value) {
+				t.Fatalf("\nexp: %+v\ngot: %+v", tt.value, decoded.Interface())
+			}
+		})
+	}
+}
+
+func TestMsgpackStruct(t *testing.T) {
+	type record struct {
+		Name  nullable.String  `msgpack:"name"`
+		Count nullable.Int64   `msgpack:"count"`
+		Ratio nullable.Float64 `msgpack:"ratio"`
+	}
+	in := record{Name: nullable.String{String: "a", Valid: true}}
+	b, err := msgpack.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var native map[string]any
+	if err := msgpack.Unmarshal(b, &native); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{"name": "a", "count": nil, "ratio": nil}
+	if !reflect.DeepEqual(native, want) {
+		t.Fatalf("\nexp: %#v\ngot: %#v", want, native)
+	}
+
+	var out record
+	if err := msgpack.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != in {
+		t.Fatalf("\nexp: %+v\ngot: %+v", in, out)
+	}
+}
+
+func TestMsgpackDecode(t *testing.T) {
+	t.Run("widening", func(t *testing.T) {
+		b, _ := msgpack.Marshal(int8(7))
+		var n nullable.Int64
+		if err := msgpack.Unmarshal(b, &n); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n != (nullable.Int64{Int64: 7, Valid: true}) {
+			t.Fatalf("unexpected value: %+v", n)
+		}
+	})
+
+	errTests := []struct {
+		name  string
+		data  any
+		value any
+	}{
+		{name: "UUID length", data: []byte{1, 2}, value: &nullable.UUID{}},
+		{name: "invalid date", data: "2017-13-01", value: &nullable.Date{}},
+		{name: "invalid JSON", data: "{", value: &nullable.RawJSON{}},
+		{name: "wrong type", data: "true", value: &nullable.Bool{}},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := msgpack.Marshal(tt.data)
+			if err := msgpack.Unmarshal(b, tt.value); err == nil {
+				t.Fatalf("expected an error, got %+v", tt.value)
+			}
+		})
+	}
+}
+
+func TestMsgpackUnregistered(t *testing.T) {
+	// Unregistered instantiations fall back to msgpack's support for
+	// encoding.BinaryMarshaler, and are encoded as opaque bin values.
+	in := nullable.Null[int16]{V: -3, Valid: true}
+	b, err := msgpack.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var raw []byte
+	if err := msgpack.Unmarshal(b, &raw); err != nil {
+		t.Fatalf("expected bin, got error: %v", err)
+	}
+	if want, _ := in.MarshalBinary(); !bytes.Equal(raw, want) {
+		t.Fatalf("\nexp: %x\ngot: %x", want, raw)
+	}
+
+	var out nullable.Null[int16]
+	if err := msgpack.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != in {
+		t.Fatalf("\nexp: %+v\ngot: %+v", in, out)
+	}
+}
+
+// equal compares decoded values, comparing times with time.Time.Equal
+// as msgpack decodes them in the local time zone.
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case *nullable.Time:
+		b := b.(*nullable.Time)
+		return a.Valid == b.Valid && a.Time.Equal(b.Time)
+	case *nullable.FormattedTime[nullable.DefaultTimeFormat]:
+		b := b.(*nullable.FormattedTime[nullable.DefaultTimeFormat])
+		return a.Valid == b.Valid && a.Time.Equal(b.Time)
+	case *nullable.Decimal:
+		return a.Equal(*b.(*nullable.Decimal)) && a.Scale() == b.(*nullable.Decimal).Scale()
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
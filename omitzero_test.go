@@ -0,0 +1,52 @@
+//go:build go1.24
+
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOmitZero(t *testing.T) {
+	type inner struct {
+		A Null[string] `json:"a,omitzero"`
+		B Null[int]    `json:"b,omitzero"`
+	}
+	type outer struct {
+		Inner    inner            `json:"inner"`
+		Optional Optional[string] `json:"optional,omitzero"`
+		List     []Null[int]      `json:"list,omitempty"`
+	}
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{
+			name: "all null",
+			v:    outer{},
+			want: `{"inner":{}}`,
+		},
+		{
+			name: "some valid",
+			v: outer{
+				Inner:    inner{B: Null[int]{V: 0, Valid: true}},
+				Optional: Optional[string]{Present: true},
+				List:     []Null[int]{{}},
+			},
+			want: `{"inner":{"b":0},"optional":null,"list":[null]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Optional defines a tri-state nullable type which, on top of Valid,
+// records whether a value (even null) was ever decoded into it.
+//
+// This makes it possible to tell apart a field that was omitted from
+// a JSON payload and a field that was explicitly set to null, which
+// is what PATCH style APIs need.
+type Optional[T any] struct {
+	V       T
+	Valid   bool // Valid is true if V is not NULL
+	Present bool // Present is true if a value, even NULL, was decoded
+}
+
+// MarshalJSON for Optional
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.V)
+}
+
+// UnmarshalJSON for Optional
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	o.Present = true
+	if bytes.EqualFold(b, nullLiteral) {
+		var zero T
+		o.V = zero
+		o.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &o.V)
+	o.Valid = err == nil
+	return err
+}
+
+// IsZero reports whether o is absent, so that fields tagged with
+// omitzero are left out of the encoded output entirely.
+func (o Optional[T]) IsZero() bool {
+	return !o.Present
+}
+
+// MarshalText implements encoding.TextMarshaler, like Null.MarshalText
+func (o Optional[T]) MarshalText() ([]byte, error) {
+	return Null[T]{V: o.V, Valid: o.Valid}.MarshalText()
+}
+
+// AppendText implements encoding.TextAppender, like Null.AppendText
+func (o Optional[T]) AppendText(b []byte) ([]byte, error) {
+	return Null[T]{V: o.V, Valid: o.Valid}.AppendText(b)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, like Null.UnmarshalText
+func (o *Optional[T]) UnmarshalText(b []byte) error {
+	o.Present = true
+	n := Null[T]{V: o.V}
+	err := n.UnmarshalText(b)
+	o.V, o.Valid = n.V, n.Valid
+	return err
+}
+
+// Scan implements the Scanner interface from database/sql
+func (o *Optional[T]) Scan(src any) error {
+	n := Null[T]{V: o.V, Valid: o.Valid}
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+
+	o.V = n.V
+	o.Valid = n.Valid
+	o.Present = true
+
+	return nil
+}
+
+// Value returns the database/sql driver value for Optional
+func (o Optional[T]) Value() (driver.Value, error) {
+	return Null[T]{V: o.V, Valid: o.Valid}.Value()
+}
+
+// Apply writes o to dst if o is present, setting dst to the
+// zero value of T if o is null. It reports whether dst was written.
+func (o Optional[T]) Apply(dst *T) bool {
+	if !o.Present {
+		return false
+	}
+	if !o.Valid {
+		var zero T
+		*dst = zero
+		return true
+	}
+	*dst = o.V
+	return true
+}
+
+// ApplyPtr writes o to dst if o is present, setting dst to nil
+// if o is null. It reports whether dst was written.
+func (o Optional[T]) ApplyPtr(dst **T) bool {
+	if !o.Present {
+		return false
+	}
+	if !o.Valid {
+		*dst = nil
+		return true
+	}
+	v := o.V
+	*dst = &v
+	return true
+}
+
+// ApplyNull writes o to dst if o is present, preserving its validity.
+// It reports whether dst was written.
+func (o Optional[T]) ApplyNull(dst *Null[T]) bool {
+	if !o.Present {
+		return false
+	}
+	*dst = Null[T]{V: o.V, Valid: o.Valid}
+	return true
+}
+
+// applier is implemented by every Optional, regardless of T,
+// so that ApplyPresent can work on them through reflection.
+type applier interface {
+	isPresent() bool
+	applyTo(dst reflect.Value) bool
+}
+
+func (o Optional[T]) isPresent() bool {
+	return o.Present
+}
+
+func (o Optional[T]) applyTo(dst reflect.Value) bool {
+	switch d := dst.Addr().Interface().(type) {
+	case *Optional[T]:
+		*d = o
+	case *Null[T]:
+		o.ApplyNull(d)
+	case *T:
+		o.Apply(d)
+	case **T:
+		o.ApplyPtr(d)
+	default:
+		return false
+	}
+	return true
+}
+
+// ApplyPresent copies every present Optional field of patch onto the
+// field with the same name in dst, leaving all other fields untouched.
+//
+// patch must be a struct or a pointer to one, and dst a pointer to a struct.
+// Destination fields may be of type Optional[T], Null[T], T or *T.
+func ApplyPresent(dst, patch any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("nullable: ApplyPresent destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+	dv = dv.Elem()
+
+	pv := reflect.ValueOf(patch)
+	if pv.Kind() == reflect.Pointer {
+		pv = pv.Elem()
+	}
+	if pv.Kind() != reflect.Struct {
+		return fmt.Errorf("nullable: ApplyPresent patch must be a struct, got %T", patch)
+	}
+
+	pt := pv.Type()
+	for i := 0; i < pt.NumField(); i++ {
+		field := pt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		opt, ok := pv.Field(i).Interface().(applier)
+		if !ok || !opt.isPresent() {
+			continue
+		}
+
+		target := dv.FieldByName(field.Name)
+		if !target.IsValid() || !target.CanSet() {
+			return fmt.Errorf("nullable: ApplyPresent destination %s has no settable field %s", dv.Type(), field.Name)
+		}
+		if !opt.applyTo(target) {
+			return fmt.Errorf("nullable: cannot apply %s to field %s of type %s", field.Type, field.Name, target.Type())
+		}
+	}
+	return nil
+}
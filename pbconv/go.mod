@@ -0,0 +1,10 @@
+module github.com/ladydascalie/nullable/pbconv
+
+go 1.22
+
+require (
+	github.com/ladydascalie/nullable v0.0.0
+	google.golang.org/protobuf v1.36.7
+)
+
+replace github.com/ladydascalie/nullable => ../
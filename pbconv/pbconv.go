@@ -0,0 +1,480 @@
+// Package pbconv converts between the types of package nullable and the
+// Protocol Buffers well-known types used for optional fields.
+//
+// A nil message is always converted to an invalid value, and an invalid
+// value to a nil message. Converters are named after the message type,
+// such as FromStringValue and ToStringValue, and prefixed with the nullable
+// type when several of them share a message, such as Int16FromInt32Value.
+//
+// Date, TimeOfDay, UUID and Decimal have no well-known type, and are carried
+// in a StringValue using their text encoding. RawJSON and JSON are carried
+// in a structpb.Value.
+package pbconv
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/ladydascalie/nullable"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// FromStringValue converts a StringValue to a String
+func FromStringValue(v *wrapperspb.StringValue) nullable.String {
+	if v == nil {
+		return nullable.String{Valid: false}
+	}
+	return nullable.String{String: v.Value, Valid: true}
+}
+
+// ToStringValue converts a String to a StringValue
+func ToStringValue(n nullable.String) *wrapperspb.StringValue {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.String(n.String)
+}
+
+// FromInt64Value converts an Int64Value to an Int64
+func FromInt64Value(v *wrapperspb.Int64Value) nullable.Int64 {
+	if v == nil {
+		return nullable.Int64{Valid: false}
+	}
+	return nullable.Int64{Int64: v.Value, Valid: true}
+}
+
+// ToInt64Value converts an Int64 to an Int64Value
+func ToInt64Value(n nullable.Int64) *wrapperspb.Int64Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Int64(n.Int64)
+}
+
+// FromInt32Value converts an Int32Value to an Int32
+func FromInt32Value(v *wrapperspb.Int32Value) nullable.Int32 {
+	if v == nil {
+		return nullable.Int32{Valid: false}
+	}
+	return nullable.Int32{Int32: v.Value, Valid: true}
+}
+
+// ToInt32Value converts an Int32 to an Int32Value
+func ToInt32Value(n nullable.Int32) *wrapperspb.Int32Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Int32(n.Int32)
+}
+
+// Int16FromInt32Value converts an Int32Value to an Int16.
+// It returns an error if the value overflows an int16.
+func Int16FromInt32Value(v *wrapperspb.Int32Value) (nullable.Int16, error) {
+	if v == nil {
+		return nullable.Int16{Valid: false}, nil
+	}
+	if v.Value < math.MinInt16 || v.Value > math.MaxInt16 {
+		return nullable.Int16{}, rangeError(v.Value, "Int16")
+	}
+	return nullable.Int16{Int16: int16(v.Value), Valid: true}, nil
+}
+
+// Int16ToInt32Value converts an Int16 to an Int32Value
+func Int16ToInt32Value(n nullable.Int16) *wrapperspb.Int32Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Int32(int32(n.Int16))
+}
+
+// Int8FromInt32Value converts an Int32Value to an Int8.
+// It returns an error if the value overflows an int8.
+func Int8FromInt32Value(v *wrapperspb.Int32Value) (nullable.Int8, error) {
+	if v == nil {
+		return nullable.Int8{Valid: false}, nil
+	}
+	if v.Value < math.MinInt8 || v.Value > math.MaxInt8 {
+		return nullable.Int8{}, rangeError(v.Value, "Int8")
+	}
+	return nullable.Int8{Int8: int8(v.Value), Valid: true}, nil
+}
+
+// Int8ToInt32Value converts an Int8 to an Int32Value
+func Int8ToInt32Value(n nullable.Int8) *wrapperspb.Int32Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Int32(int32(n.Int8))
+}
+
+// FromUInt64Value converts a UInt64Value to a Uint64
+func FromUInt64Value(v *wrapperspb.UInt64Value) nullable.Uint64 {
+	if v == nil {
+		return nullable.Uint64{Valid: false}
+	}
+	return nullable.Uint64{Uint64: v.Value, Valid: true}
+}
+
+// ToUInt64Value converts a Uint64 to a UInt64Value
+func ToUInt64Value(n nullable.Uint64) *wrapperspb.UInt64Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.UInt64(n.Uint64)
+}
+
+// FromUInt32Value converts a UInt32Value to a Uint32
+func FromUInt32Value(v *wrapperspb.UInt32Value) nullable.Uint32 {
+	if v == nil {
+		return nullable.Uint32{Valid: false}
+	}
+	return nullable.Uint32{Uint32: v.Value, Valid: true}
+}
+
+// ToUInt32Value converts a Uint32 to a UInt32Value
+func ToUInt32Value(n nullable.Uint32) *wrapperspb.UInt32Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.UInt32(n.Uint32)
+}
+
+// Uint16FromUInt32Value converts a UInt32Value to a Uint16.
+// It returns an error if the value overflows a uint16.
+func Uint16FromUInt32Value(v *wrapperspb.UInt32Value) (nullable.Uint16, error) {
+	if v == nil {
+		return nullable.Uint16{Valid: false}, nil
+	}
+	if v.Value > math.MaxUint16 {
+		return nullable.Uint16{}, rangeError(v.Value, "Uint16")
+	}
+	return nullable.Uint16{Uint16: uint16(v.Value), Valid: true}, nil
+}
+
+// Uint16ToUInt32Value converts a Uint16 to a UInt32Value
+func Uint16ToUInt32Value(n nullable.Uint16) *wrapperspb.UInt32Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.UInt32(uint32(n.Uint16))
+}
+
+// Uint8FromUInt32Value converts a UInt32Value to a Uint8.
+// It returns an error if the value overflows a uint8.
+func Uint8FromUInt32Value(v *wrapperspb.UInt32Value) (nullable.Uint8, error) {
+	if v == nil {
+		return nullable.Uint8{Valid: false}, nil
+	}
+	if v.Value > math.MaxUint8 {
+		return nullable.Uint8{}, rangeError(v.Value, "Uint8")
+	}
+	return nullable.Uint8{Uint8: uint8(v.Value), Valid: true}, nil
+}
+
+// Uint8ToUInt32Value converts a Uint8 to a UInt32Value
+func Uint8ToUInt32Value(n nullable.Uint8) *wrapperspb.UInt32Value {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.UInt32(uint32(n.Uint8))
+}
+
+// FromDoubleValue converts a DoubleValue to a Float64
+func FromDoubleValue(v *wrapperspb.DoubleValue) nullable.Float64 {
+	if v == nil {
+		return nullable.Float64{Valid: false}
+	}
+	return nullable.Float64{Float64: v.Value, Valid: true}
+}
+
+// ToDoubleValue converts a Float64 to a DoubleValue
+func ToDoubleValue(n nullable.Float64) *wrapperspb.DoubleValue {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Double(n.Float64)
+}
+
+// FromFloatValue converts a FloatValue to a Float32
+func FromFloatValue(v *wrapperspb.FloatValue) nullable.Float32 {
+	if v == nil {
+		return nullable.Float32{Valid: false}
+	}
+	return nullable.Float32{Float32: v.Value, Valid: true}
+}
+
+// ToFloatValue converts a Float32 to a FloatValue
+func ToFloatValue(n nullable.Float32) *wrapperspb.FloatValue {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Float(n.Float32)
+}
+
+// FromBoolValue converts a BoolValue to a Bool
+func FromBoolValue(v *wrapperspb.BoolValue) nullable.Bool {
+	if v == nil {
+		return nullable.Bool{Valid: false}
+	}
+	return nullable.Bool{Bool: v.Value, Valid: true}
+}
+
+// ToBoolValue converts a Bool to a BoolValue
+func ToBoolValue(n nullable.Bool) *wrapperspb.BoolValue {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Bool(n.Bool)
+}
+
+// FromBytesValue converts a BytesValue to Bytes
+func FromBytesValue(v *wrapperspb.BytesValue) nullable.Bytes {
+	if v == nil {
+		return nullable.Bytes{Valid: false}
+	}
+	return nullable.Bytes{Bytes: v.Value, Valid: true}
+}
+
+// ToBytesValue converts Bytes to a BytesValue
+func ToBytesValue(n nullable.Bytes) *wrapperspb.BytesValue {
+	if !n.Valid {
+		return nil
+	}
+	return wrapperspb.Bytes(n.Bytes)
+}
+
+// BytesAsFromBytesValue converts a BytesValue to BytesAs
+func BytesAsFromBytesValue[E nullable.BinaryEncoding](v *wrapperspb.BytesValue) nullable.BytesAs[E] {
+	return nullable.BytesAs[E](FromBytesValue(v))
+}
+
+// BytesAsToBytesValue converts BytesAs to a BytesValue
+func BytesAsToBytesValue[E nullable.BinaryEncoding](n nullable.BytesAs[E]) *wrapperspb.BytesValue {
+	return ToBytesValue(nullable.Bytes(n))
+}
+
+// FromTimestamp converts a Timestamp to a Time in UTC
+func FromTimestamp(v *timestamppb.Timestamp) nullable.Time {
+	if v == nil {
+		return nullable.Time{Valid: false}
+	}
+	return nullable.Time{Time: v.AsTime(), Valid: true}
+}
+
+// ToTimestamp converts a Time to a Timestamp
+func ToTimestamp(n nullable.Time) *timestamppb.Timestamp {
+	if !n.Valid {
+		return nil
+	}
+	return timestamppb.New(n.Time)
+}
+
+// FormattedTimeFromTimestamp converts a Timestamp to a FormattedTime in UTC
+func FormattedTimeFromTimestamp[F nullable.TimeFormat](v *timestamppb.Timestamp) nullable.FormattedTime[F] {
+	if v == nil {
+		return nullable.FormattedTime[F]{Valid: false}
+	}
+	return nullable.FormattedTime[F]{Time: v.AsTime(), Valid: true}
+}
+
+// FormattedTimeToTimestamp converts a FormattedTime to a Timestamp
+func FormattedTimeToTimestamp[F nullable.TimeFormat](n nullable.FormattedTime[F]) *timestamppb.Timestamp {
+	if !n.Valid {
+		return nil
+	}
+	return timestamppb.New(n.Time)
+}
+
+// FromDuration converts a protobuf Duration to a Duration.
+// Durations out of the range of time.Duration are clamped.
+func FromDuration(v *durationpb.Duration) nullable.Duration {
+	if v == nil {
+		return nullable.Duration{Valid: false}
+	}
+	return nullable.Duration{Duration: v.AsDuration(), Valid: true}
+}
+
+// ToDuration converts a Duration to a protobuf Duration
+func ToDuration(n nullable.Duration) *durationpb.Duration {
+	if !n.Valid {
+		return nil
+	}
+	return durationpb.New(n.Duration)
+}
+
+// DateFromStringValue converts a "2006-01-02" StringValue to a Date
+func DateFromStringValue(v *wrapperspb.StringValue) (nullable.Date, error) {
+	var n nullable.Date
+	return n, fromText(&n, v)
+}
+
+// DateToStringValue converts a Date to a "2006-01-02" StringValue
+func DateToStringValue(n nullable.Date) *wrapperspb.StringValue {
+	return toText(n, n.Valid)
+}
+
+// TimeOfDayFromStringValue converts a "15:04:05" StringValue to a TimeOfDay
+func TimeOfDayFromStringValue(v *wrapperspb.StringValue) (nullable.TimeOfDay, error) {
+	var n nullable.TimeOfDay
+	return n, fromText(&n, v)
+}
+
+// TimeOfDayToStringValue converts a TimeOfDay to a "15:04:05.999999999" StringValue
+func TimeOfDayToStringValue(n nullable.TimeOfDay) *wrapperspb.StringValue {
+	return toText(n, n.Valid)
+}
+
+// UUIDFromStringValue converts a StringValue to a UUID, accepting the forms ParseUUID does
+func UUIDFromStringValue(v *wrapperspb.StringValue) (nullable.UUID, error) {
+	var n nullable.UUID
+	return n, fromText(&n, v)
+}
+
+// UUIDToStringValue converts a UUID to a canonical StringValue
+func UUIDToStringValue(n nullable.UUID) *wrapperspb.StringValue {
+	return toText(n, n.Valid)
+}
+
+// DecimalFromStringValue converts a StringValue to a Decimal, accepting the forms ParseDecimal does
+func DecimalFromStringValue(v *wrapperspb.StringValue) (nullable.Decimal, error) {
+	var n nullable.Decimal
+	return n, fromText(&n, v)
+}
+
+// DecimalToStringValue converts a Decimal to a StringValue in plain decimal notation
+func DecimalToStringValue(n nullable.Decimal) *wrapperspb.StringValue {
+	return toText(n, n.Valid)
+}
+
+// RawJSONFromValue converts a structpb Value to a RawJSON.
+// A nil Value is converted to a null RawJSON, and a NullValue to the JSON null literal.
+func RawJSONFromValue(v *structpb.Value) (nullable.RawJSON, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return nullable.RawJSON(b), nil
+}
+
+// RawJSONToValue converts a RawJSON to a structpb Value.
+// It returns an error if n does not hold valid JSON.
+func RawJSONToValue(n nullable.RawJSON) (*structpb.Value, error) {
+	if n.IsNull() {
+		return nil, nil
+	}
+	v := new(structpb.Value)
+	if err := v.UnmarshalJSON(n); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// JSONFromValue converts a structpb Value to a JSON[T], going through encoding/json.
+// Both a nil Value and a NullValue are converted to an invalid JSON[T].
+func JSONFromValue[T any](v *structpb.Value) (nullable.JSON[T], error) {
+	var n nullable.JSON[T]
+	if v == nil {
+		return n, nil
+	}
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return n, err
+	}
+	return n, json.Unmarshal(b, &n)
+}
+
+// JSONToValue converts a JSON[T] to a structpb Value, going through encoding/json
+func JSONToValue[T any](n nullable.JSON[T]) (*structpb.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	b, err := json.Marshal(n.V)
+	if err != nil {
+		return nil, err
+	}
+	v := new(structpb.Value)
+	if err := v.UnmarshalJSON(b); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Scalar lists the types held by the wrapperspb messages
+type Scalar interface {
+	string | []byte | bool | int32 | int64 | uint32 | uint64 | float32 | float64
+}
+
+// Wrapper is implemented by the wrapperspb messages holding a T,
+// such as *wrapperspb.StringValue for string.
+type Wrapper[T Scalar] interface {
+	proto.Message
+	GetValue() T
+}
+
+// FromWrapper converts any wrapperspb message to a Null[T]:
+//
+//	name := pbconv.FromWrapper[string](req.Name)
+func FromWrapper[T Scalar, W Wrapper[T]](v W) nullable.Null[T] {
+	if !v.ProtoReflect().IsValid() {
+		return nullable.Null[T]{Valid: false}
+	}
+	return nullable.Null[T]{V: v.GetValue(), Valid: true}
+}
+
+// ToWrapper converts a Null[T] to the wrapperspb message W holding a T:
+//
+//	req.Name = pbconv.ToWrapper[*wrapperspb.StringValue](name)
+func ToWrapper[W Wrapper[T], T Scalar](n nullable.Null[T]) W {
+	var v W
+	if !n.Valid {
+		return v
+	}
+	m := v.ProtoReflect().New()
+	m.Set(m.Descriptor().Fields().ByName("value"), protoreflect.ValueOf(n.V))
+	return m.Interface().(W)
+}
+
+// OptionalFromWrapper converts any wrapperspb message to an Optional[T].
+// As a wrapper cannot tell an absent value from a null one,
+// the result is only Present if v is not nil.
+func OptionalFromWrapper[T Scalar, W Wrapper[T]](v W) nullable.Optional[T] {
+	n := FromWrapper[T](v)
+	return nullable.Optional[T]{V: n.V, Valid: n.Valid, Present: n.Valid}
+}
+
+// OptionalToWrapper converts an Optional[T] to the wrapperspb message W holding a T.
+// Absent and null values are both converted to nil.
+func OptionalToWrapper[W Wrapper[T], T Scalar](o nullable.Optional[T]) W {
+	return ToWrapper[W](nullable.Null[T]{V: o.V, Valid: o.Valid})
+}
+
+// fromText decodes the text held by v into n, leaving n invalid if v is nil
+func fromText(n interface{ UnmarshalText([]byte) error }, v *wrapperspb.StringValue) error {
+	if v == nil {
+		return nil
+	}
+	return n.UnmarshalText([]byte(v.Value))
+}
+
+// toText returns the text encoding of n as a StringValue, or nil if n is invalid
+func toText(n interface{ MarshalText() ([]byte, error) }, valid bool) *wrapperspb.StringValue {
+	if !valid {
+		return nil
+	}
+	// MarshalText never fails for the types converted to text here.
+	b, _ := n.MarshalText()
+	return wrapperspb.String(string(b))
+}
+
+func rangeError(v any, name string) error {
+	return fmt.Errorf("pbconv: %d is out of range for %s", v, name)
+}
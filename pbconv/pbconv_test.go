@@ -0,0 +1,362 @@
+package pbconv
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ladydascalie/nullable"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWrappers(t *testing.T) {
+	stamp := time.Date(2017, 11, 24, 10, 30, 0, 123456789, time.UTC)
+
+	var tests = []struct {
+		name    string
+		from    func() (any, error) // converts msg to a nullable value
+		to      func() proto.Message
+		want    any
+		wantMsg proto.Message
+	}{
+		{
+			name:    "String",
+			from:    func() (any, error) { return FromStringValue(wrapperspb.String("a")), nil },
+			to:      func() proto.Message { return ToStringValue(nullable.String{String: "a", Valid: true}) },
+			want:    nullable.String{String: "a", Valid: true},
+			wantMsg: wrapperspb.String("a"),
+		},
+		{
+			name:    "Int64",
+			from:    func() (any, error) { return FromInt64Value(wrapperspb.Int64(-64)), nil },
+			to:      func() proto.Message { return ToInt64Value(nullable.Int64{Int64: -64, Valid: true}) },
+			want:    nullable.Int64{Int64: -64, Valid: true},
+			wantMsg: wrapperspb.Int64(-64),
+		},
+		{
+			name:    "Int32",
+			from:    func() (any, error) { return FromInt32Value(wrapperspb.Int32(-32)), nil },
+			to:      func() proto.Message { return ToInt32Value(nullable.Int32{Int32: -32, Valid: true}) },
+			want:    nullable.Int32{Int32: -32, Valid: true},
+			wantMsg: wrapperspb.Int32(-32),
+		},
+		{
+			name:    "Int16",
+			from:    func() (any, error) { return Int16FromInt32Value(wrapperspb.Int32(-16)) },
+			to:      func() proto.Message { return Int16ToInt32Value(nullable.Int16{Int16: -16, Valid: true}) },
+			want:    nullable.Int16{Int16: -16, Valid: true},
+			wantMsg: wrapperspb.Int32(-16),
+		},
+		{
+			name:    "Int8",
+			from:    func() (any, error) { return Int8FromInt32Value(wrapperspb.Int32(-8)) },
+			to:      func() proto.Message { return Int8ToInt32Value(nullable.Int8{Int8: -8, Valid: true}) },
+			want:    nullable.Int8{Int8: -8, Valid: true},
+			wantMsg: wrapperspb.Int32(-8),
+		},
+		{
+			name:    "Uint64",
+			from:    func() (any, error) { return FromUInt64Value(wrapperspb.UInt64(1 << 63)), nil },
+			to:      func() proto.Message { return ToUInt64Value(nullable.Uint64{Uint64: 1 << 63, Valid: true}) },
+			want:    nullable.Uint64{Uint64: 1 << 63, Valid: true},
+			wantMsg: wrapperspb.UInt64(1 << 63),
+		},
+		{
+			name:    "Uint32",
+			from:    func() (any, error) { return FromUInt32Value(wrapperspb.UInt32(32)), nil },
+			to:      func() proto.Message { return ToUInt32Value(nullable.Uint32{Uint32: 32, Valid: true}) },
+			want:    nullable.Uint32{Uint32: 32, Valid: true},
+			wantMsg: wrapperspb.UInt32(32),
+		},
+		{
+			name:    "Uint16",
+			from:    func() (any, error) { return Uint16FromUInt32Value(wrapperspb.UInt32(16)) },
+			to:      func() proto.Message { return Uint16ToUInt32Value(nullable.Uint16{Uint16: 16, Valid: true}) },
+			want:    nullable.Uint16{Uint16: 16, Valid: true},
+			wantMsg: wrapperspb.UInt32(16),
+		},
+		{
+			name:    "Uint8",
+			from:    func() (any, error) { return Uint8FromUInt32Value(wrapperspb.UInt32(8)) },
+			to:      func() proto.Message { return Uint8ToUInt32Value(nullable.Uint8{Uint8: 8, Valid: true}) },
+			want:    nullable.Uint8{Uint8: 8, Valid: true},
+			wantMsg: wrapperspb.UInt32(8),
+		},
+		{
+			name:    "Float64",
+			from:    func() (any, error) { return FromDoubleValue(wrapperspb.Double(1.5)), nil },
+			to:      func() proto.Message { return ToDoubleValue(nullable.Float64{Float64: 1.5, Valid: true}) },
+			want:    nullable.Float64{Float64: 1.5, Valid: true},
+			wantMsg: wrapperspb.Double(1.5),
+		},
+		{
+			name:    "Float32",
+			from:    func() (any, error) { return FromFloatValue(wrapperspb.Float(0.25)), nil },
+			to:      func() proto.Message { return ToFloatValue(nullable.Float32{Float32: 0.25, Valid: true}) },
+			want:    nullable.Float32{Float32: 0.25, Valid: true},
+			wantMsg: wrapperspb.Float(0.25),
+		},
+		{
+			name:    "Bool",
+			from:    func() (any, error) { return FromBoolValue(wrapperspb.Bool(false)), nil },
+			to:      func() proto.Message { return ToBoolValue(nullable.Bool{Bool: false, Valid: true}) },
+			want:    nullable.Bool{Bool: false, Valid: true},
+			wantMsg: wrapperspb.Bool(false),
+		},
+		{
+			name:    "Bytes",
+			from:    func() (any, error) { return FromBytesValue(wrapperspb.Bytes([]byte{1, 2})), nil },
+			to:      func() proto.Message { return ToBytesValue(nullable.Bytes{Bytes: []byte{1, 2}, Valid: true}) },
+			want:    nullable.Bytes{Bytes: []byte{1, 2}, Valid: true},
+			wantMsg: wrapperspb.Bytes([]byte{1, 2}),
+		},
+		{
+			name: "BytesAs",
+			from: func() (any, error) {
+				return BytesAsFromBytesValue[nullable.HexEncoding](wrapperspb.Bytes([]byte{1, 2})), nil
+			},
+			to: func() proto.Message {
+				return BytesAsToBytesValue(nullable.BytesAs[nullable.HexEncoding]{Bytes: []byte{1, 2}, Valid: true})
+			},
+			want:    nullable.BytesAs[nullable.HexEncoding]{Bytes: []byte{1, 2}, Valid: true},
+			wantMsg: wrapperspb.Bytes([]byte{1, 2}),
+		},
+		{
+			name:    "Time",
+			from:    func() (any, error) { return FromTimestamp(timestamppb.New(stamp)), nil },
+			to:      func() proto.Message { return ToTimestamp(nullable.Time{Time: stamp, Valid: true}) },
+			want:    nullable.Time{Time: stamp, Valid: true},
+			wantMsg: timestamppb.New(stamp),
+		},
+		{
+			name: "FormattedTime",
+			from: func() (any, error) {
+				return FormattedTimeFromTimestamp[nullable.DefaultTimeFormat](timestamppb.New(stamp)), nil
+			},
+			to: func() proto.Message {
+				return FormattedTimeToTimestamp(nullable.FormattedTime[nullable.DefaultTimeFormat]{Time: stamp, Valid: true})
+			},
+			want:    nullable.FormattedTime[nullable.DefaultTimeFormat]{Time: stamp, Valid: true},
+			wantMsg: timestamppb.New(stamp),
+		},
+		{
+			name:    "Duration",
+			from:    func() (any, error) { return FromDuration(durationpb.New(90 * time.Second)), nil },
+			to:      func() proto.Message { return ToDuration(nullable.Duration{Duration: 90 * time.Second, Valid: true}) },
+			want:    nullable.Duration{Duration: 90 * time.Second, Valid: true},
+			wantMsg: durationpb.New(90 * time.Second),
+		},
+		{
+			name: "Date",
+			from: func() (any, error) { return DateFromStringValue(wrapperspb.String("2017-11-24")) },
+			to: func() proto.Message {
+				return DateToStringValue(nullable.Date{Year: 2017, Month: time.November, Day: 24, Valid: true})
+			},
+			want:    nullable.Date{Year: 2017, Month: time.November, Day: 24, Valid: true},
+			wantMsg: wrapperspb.String("2017-11-24"),
+		},
+		{
+			name: "TimeOfDay",
+			from: func() (any, error) { return TimeOfDayFromStringValue(wrapperspb.String("10:30:05")) },
+			to: func() proto.Message {
+				return TimeOfDayToStringValue(nullable.TimeOfDay{Hour: 10, Minute: 30, Second: 5, Valid: true})
+			},
+			want:    nullable.TimeOfDay{Hour: 10, Minute: 30, Second: 5, Valid: true},
+			wantMsg: wrapperspb.String("10:30:05"),
+		},
+		{
+			name: "UUID",
+			from: func() (any, error) {
+				return UUIDFromStringValue(wrapperspb.String("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+			},
+			to: func() proto.Message {
+				id, _ := nullable.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+				return UUIDToStringValue(id)
+			},
+			want: func() nullable.UUID {
+				id, _ := nullable.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+				return id
+			}(),
+			wantMsg: wrapperspb.String("6ba7b810-9dad-11d1-80b4-00c04fd430c8"),
+		},
+		{
+			name: "Decimal",
+			from: func() (any, error) { return DecimalFromStringValue(wrapperspb.String("-12.3400")) },
+			to: func() proto.Message {
+				return DecimalToStringValue(nullable.NewDecimal(-123400, 4))
+			},
+			want:    nullable.NewDecimal(-123400, 4),
+			wantMsg: wrapperspb.String("-12.3400"),
+		},
+		{
+			name: "Null[string]",
+			from: func() (any, error) { return FromWrapper[string](wrapperspb.String("a")), nil },
+			to: func() proto.Message {
+				return ToWrapper[*wrapperspb.StringValue](nullable.Null[string]{V: "a", Valid: true})
+			},
+			want:    nullable.Null[string]{V: "a", Valid: true},
+			wantMsg: wrapperspb.String("a"),
+		},
+		{
+			name: "Null[int64]",
+			from: func() (any, error) { return FromWrapper[int64](wrapperspb.Int64(7)), nil },
+			to: func() proto.Message {
+				return ToWrapper[*wrapperspb.Int64Value](nullable.Null[int64]{V: 7, Valid: true})
+			},
+			want:    nullable.Null[int64]{V: 7, Valid: true},
+			wantMsg: wrapperspb.Int64(7),
+		},
+		{
+			name: "Null[float32]",
+			from: func() (any, error) { return FromWrapper[float32](wrapperspb.Float(0.5)), nil },
+			to: func() proto.Message {
+				return ToWrapper[*wrapperspb.FloatValue](nullable.Null[float32]{V: 0.5, Valid: true})
+			},
+			want:    nullable.Null[float32]{V: 0.5, Valid: true},
+			wantMsg: wrapperspb.Float(0.5),
+		},
+		{
+			name: "Null[[]byte]",
+			from: func() (any, error) { return FromWrapper[[]byte](wrapperspb.Bytes([]byte{1})), nil },
+			to: func() proto.Message {
+				return ToWrapper[*wrapperspb.BytesValue](nullable.Null[[]byte]{V: []byte{1}, Valid: true})
+			},
+			want:    nullable.Null[[]byte]{V: []byte{1}, Valid: true},
+			wantMsg: wrapperspb.Bytes([]byte{1}),
+		},
+		{
+			name: "Optional[bool]",
+			from: func() (any, error) { return OptionalFromWrapper[bool](wrapperspb.Bool(true)), nil },
+			to: func() proto.Message {
+				return OptionalToWrapper[*wrapperspb.BoolValue](nullable.Optional[bool]{V: true, Valid: true, Present: true})
+			},
+			want:    nullable.Optional[bool]{V: true, Valid: true, Present: true},
+			wantMsg: wrapperspb.Bool(true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.from()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("\nexp: %+v\ngot: %+v", tt.want, got)
+			}
+			if msg := tt.to(); !proto.Equal(msg, tt.wantMsg) {
+				t.Fatalf("\nexp: %v\ngot: %v", tt.wantMsg, msg)
+			}
+		})
+	}
+}
+
+func TestNil(t *testing.T) {
+	if got := FromStringValue(nil); got.Valid {
+		t.Fatalf("expected invalid String, got %+v", got)
+	}
+	if got := ToStringValue(nullable.String{}); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+	if got := FromTimestamp(nil); got.Valid {
+		t.Fatalf("expected invalid Time, got %+v", got)
+	}
+	if got := ToTimestamp(nullable.Time{}); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+	if got, err := Int8FromInt32Value(nil); err != nil || got.Valid {
+		t.Fatalf("expected invalid Int8, got %+v, %v", got, err)
+	}
+	if got, err := DateFromStringValue(nil); err != nil || got.Valid {
+		t.Fatalf("expected invalid Date, got %+v, %v", got, err)
+	}
+	if got := DecimalToStringValue(nullable.Decimal{}); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+	if got := FromWrapper[int32]((*wrapperspb.Int32Value)(nil)); got.Valid {
+		t.Fatalf("expected invalid Null, got %+v", got)
+	}
+	if got := ToWrapper[*wrapperspb.Int32Value](nullable.Null[int32]{}); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+	if got := OptionalFromWrapper[int32]((*wrapperspb.Int32Value)(nil)); got.Present || got.Valid {
+		t.Fatalf("expected absent Optional, got %+v", got)
+	}
+}
+
+func TestRange(t *testing.T) {
+	if _, err := Int8FromInt32Value(wrapperspb.Int32(128)); err == nil {
+		t.Fatal("expected an error for 128 into Int8")
+	}
+	if _, err := Int16FromInt32Value(wrapperspb.Int32(-32769)); err == nil {
+		t.Fatal("expected an error for -32769 into Int16")
+	}
+	if _, err := Uint8FromUInt32Value(wrapperspb.UInt32(256)); err == nil {
+		t.Fatal("expected an error for 256 into Uint8")
+	}
+	if _, err := Uint16FromUInt32Value(wrapperspb.UInt32(65536)); err == nil {
+		t.Fatal("expected an error for 65536 into Uint16")
+	}
+	if _, err := UUIDFromStringValue(wrapperspb.String("nope")); err == nil {
+		t.Fatal("expected an error for an invalid UUID")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	v, err := structpb.NewValue(map[string]any{"a": 1.0, "b": []any{"x", nil}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("RawJSON", func(t *testing.T) {
+		raw, err := RawJSONFromValue(v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		back, err := RawJSONToValue(raw)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !proto.Equal(back, v) {
+			t.Fatalf("\nexp: %v\ngot: %v", v, back)
+		}
+		if raw, _ := RawJSONFromValue(nil); !raw.IsNull() {
+			t.Fatalf("expected null RawJSON, got %s", raw)
+		}
+		if raw, _ := RawJSONFromValue(structpb.NewNullValue()); !raw.IsJSONNull() {
+			t.Fatalf("expected JSON null, got %s", raw)
+		}
+		if _, err := RawJSONToValue(nullable.RawJSON("{")); err == nil {
+			t.Fatal("expected an error for invalid JSON")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		type doc struct {
+			A int      `json:"a"`
+			B []string `json:"b"`
+		}
+		got, err := JSONFromValue[doc](v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := nullable.JSON[doc]{V: doc{A: 1, B: []string{"x", ""}}, Valid: true}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("\nexp: %+v\ngot: %+v", want, got)
+		}
+		back, err := JSONToValue(nullable.JSON[doc]{V: doc{A: 2}, Valid: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if a := back.GetStructValue().GetFields()["a"].GetNumberValue(); a != 2 {
+			t.Fatalf("expected a to be 2, got %v", a)
+		}
+		if got, _ := JSONFromValue[doc](structpb.NewNullValue()); got.Valid {
+			t.Fatalf("expected invalid JSON, got %+v", got)
+		}
+	})
+}
@@ -1,9 +1,11 @@
 package nullable
 
 import (
-	"database/sql"
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
 )
 
 // RawJSON aliases json.RawMessage
@@ -29,18 +31,84 @@ func (n *RawJSON) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// Scan implements the Scanner interface from database/sql
+// MarshalText implements encoding.TextMarshaler
+func (n RawJSON) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n RawJSON) AppendText(b []byte) ([]byte, error) {
+	return append(b, n...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Non-empty text must be valid JSON.
+func (n *RawJSON) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*n = nil
+		return nil
+	}
+	if !json.Valid(b) {
+		return errors.New("nullable: invalid JSON text for RawJSON")
+	}
+	*n = append(RawJSON{}, b...)
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// A NULL column is scanned as an empty RawJSON, while the JSON null
+// literal is kept as is. The source is copied and must be valid JSON.
 func (n *RawJSON) Scan(src any) error {
-	var a sql.NullString
-	if err := a.Scan(src); err != nil {
-		return err
+	var b []byte
+	switch src := src.(type) {
+	case nil:
+		*n = nil
+		return nil
+	case string:
+		b = []byte(src)
+	case []byte:
+		b = append([]byte{}, src...)
+	default:
+		return fmt.Errorf("nullable: cannot scan type %T into RawJSON", src)
+	}
+	if !json.Valid(b) {
+		return errors.New("nullable: invalid JSON scanned into RawJSON")
 	}
-	jsn := RawJSON([]byte(a.String))
-	*n = jsn
+	*n = b
 	return nil
 }
 
-// Value returns the database/sql driver value for RawJson
+// Value returns the database/sql driver value for RawJson.
+// An empty RawJSON is written as NULL, anything else must be valid JSON.
 func (n RawJSON) Value() (driver.Value, error) {
+	if len(n) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(n) {
+		return nil, errors.New("nullable: RawJSON value is not valid JSON")
+	}
 	return string(n), nil
 }
+
+// IsNull reports whether n is empty, and as such stands for SQL NULL
+func (n RawJSON) IsNull() bool {
+	return len(n) == 0
+}
+
+// IsJSONNull reports whether n holds the JSON null literal
+func (n RawJSON) IsJSONNull() bool {
+	return bytes.Equal(bytes.TrimSpace(n), nullLiteral)
+}
+
+// Compact returns a copy of n with insignificant whitespace removed.
+// An empty RawJSON is returned unchanged.
+func (n RawJSON) Compact() (RawJSON, error) {
+	if len(n) == 0 {
+		return n, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
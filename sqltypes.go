@@ -1,3 +1,8 @@
+// Package nullable provides nullable types for use with JSON and databases.
+//
+// The package-level variables which configure encoding, such as TimeLayout,
+// are read without synchronization: set them once, during program
+// initialization, before any value is encoded or decoded.
 package nullable
 
 import (
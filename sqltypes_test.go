@@ -3,8 +3,15 @@ package nullable
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
 	"encoding/json"
+	"encoding/xml"
+	"flag"
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -1194,7 +1201,7 @@ func TestNullBoxing(t *testing.T) {
 				t.Fatalf("unexpected error: %v", err)
 			}
 
-			if v != 123 {
+			if v != int64(123) {
 				t.Fatalf("unexpected value: %d", v)
 			}
 		})
@@ -1290,6 +1297,30 @@ func TestNullBoxing(t *testing.T) {
 			}
 		})
 
+		t.Run("Scan error", func(t *testing.T) {
+			p := Null[Person]{V: Person{Name: "John"}, Valid: true}
+			if err := p.Scan("not json"); err == nil {
+				t.Fatalf("expected error")
+			}
+
+			if p.Valid {
+				t.Fatalf("expected not valid")
+			}
+		})
+
+		t.Run("Value invalid", func(t *testing.T) {
+			invalid := Null[Person]{V: p, Valid: false}
+
+			v, err := invalid.Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if v != nil {
+				t.Fatalf("unexpected value: %+s", v)
+			}
+		})
+
 		t.Run("Value null", func(t *testing.T) {
 			var complex2 Null[Person]
 
@@ -1298,9 +1329,7 @@ func TestNullBoxing(t *testing.T) {
 				t.Fatalf("unexpected error: %v", err)
 			}
 
-			raw, _ := json.Marshal(Person{})
-
-			if bytes.Compare(v.([]byte), raw) != 0 {
+			if v != nil {
 				t.Fatalf("unexpected value: %+s", v)
 			}
 
@@ -1314,3 +1343,2647 @@ func TestNullBoxing(t *testing.T) {
 		})
 	})
 }
+
+func TestOptional(t *testing.T) {
+	type patch struct {
+		Name  Optional[string] `json:"name"`
+		Age   Optional[int]    `json:"age"`
+		Email Optional[string] `json:"email"`
+	}
+
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		var p patch
+		if err := json.Unmarshal([]byte(`{"name":"John","email":null}`), &p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !p.Name.Present || !p.Name.Valid || p.Name.V != "John" {
+			t.Fatalf("unexpected name: %+v", p.Name)
+		}
+
+		if p.Age.Present || p.Age.Valid {
+			t.Fatalf("expected age to be absent: %+v", p.Age)
+		}
+
+		if !p.Email.Present || p.Email.Valid {
+			t.Fatalf("expected email to be present and null: %+v", p.Email)
+		}
+	})
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		p := patch{
+			Name:  Optional[string]{V: "John", Valid: true, Present: true},
+			Email: Optional[string]{Present: true},
+		}
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		exp := `{"name":"John","age":null,"email":null}`
+		if string(b) != exp {
+			t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+		}
+	})
+
+	t.Run("IsZero", func(t *testing.T) {
+		if !(Optional[int]{}).IsZero() {
+			t.Fatalf("expected absent value to be zero")
+		}
+
+		if (Optional[int]{Present: true}).IsZero() {
+			t.Fatalf("expected present null to not be zero")
+		}
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var o Optional[int64]
+		if err := o.Scan(int64(123)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !o.Present || !o.Valid || o.V != 123 {
+			t.Fatalf("unexpected value: %+v", o)
+		}
+	})
+
+	t.Run("Scan null", func(t *testing.T) {
+		o := Optional[int64]{V: 123, Valid: true}
+		if err := o.Scan(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !o.Present || o.Valid {
+			t.Fatalf("unexpected value: %+v", o)
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := Optional[string]{V: "hello", Valid: true, Present: true}.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if v != "hello" {
+			t.Fatalf("unexpected value: %v", v)
+		}
+	})
+
+	t.Run("Value null", func(t *testing.T) {
+		v, err := Optional[Person]{V: Person{Name: "John"}, Present: true}.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if v != nil {
+			t.Fatalf("unexpected value: %v", v)
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		name := "Jane"
+		if (Optional[string]{}).Apply(&name) {
+			t.Fatalf("absent value should not be applied")
+		}
+
+		if !(Optional[string]{V: "John", Valid: true, Present: true}).Apply(&name) || name != "John" {
+			t.Fatalf("unexpected value: %q", name)
+		}
+
+		if !(Optional[string]{Present: true}).Apply(&name) || name != "" {
+			t.Fatalf("unexpected value: %q", name)
+		}
+	})
+
+	t.Run("ApplyPresent", func(t *testing.T) {
+		email := "john@example.com"
+		type user struct {
+			Name  string
+			Age   Null[int]
+			Email *string
+		}
+		u := user{
+			Name:  "Jane",
+			Age:   Null[int]{V: 30, Valid: true},
+			Email: &email,
+		}
+
+		var p patch
+		if err := json.Unmarshal([]byte(`{"name":"John","email":null}`), &p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ApplyPresent(&u, p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if u.Name != "John" {
+			t.Fatalf("unexpected name: %q", u.Name)
+		}
+
+		if !u.Age.Valid || u.Age.V != 30 {
+			t.Fatalf("absent age should be untouched: %+v", u.Age)
+		}
+
+		if u.Email != nil {
+			t.Fatalf("expected email to be cleared: %v", *u.Email)
+		}
+	})
+
+	t.Run("ApplyPresent mismatched type", func(t *testing.T) {
+		type user struct {
+			Name int
+		}
+		p := patch{Name: Optional[string]{V: "John", Valid: true, Present: true}}
+
+		var u user
+		if err := ApplyPresent(&u, p); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+
+	t.Run("ApplyPresent non pointer", func(t *testing.T) {
+		if err := ApplyPresent(patch{}, patch{}); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+}
+
+func TestNull_MarshalJSON(t *testing.T) {
+	type inner struct {
+		A Null[string] `json:"a"`
+		B Null[int]    `json:"b"`
+	}
+	type outer struct {
+		Inner inner                `json:"inner"`
+		List  []Null[int]          `json:"list"`
+		Map   map[string]Null[int] `json:"map"`
+	}
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{
+			name: "null",
+			v:    Null[int]{},
+			want: `null`,
+		},
+		{
+			name: "nested struct",
+			v:    inner{A: Null[string]{V: "a", Valid: true}},
+			want: `{"a":"a","b":null}`,
+		},
+		{
+			name: "slices and maps",
+			v: outer{
+				List: []Null[int]{{V: 1, Valid: true}, {}},
+				Map:  map[string]Null[int]{"x": {V: 2, Valid: true}, "y": {}},
+			},
+			want: `{"inner":{"a":null,"b":null},"list":[1,null],"map":{"x":2,"y":null}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{ IsZero() bool }
+		want bool
+	}{
+		{name: "null", v: Null[int]{}, want: true},
+		{name: "null with value", v: Null[int]{V: 1}, want: true},
+		{name: "valid zero", v: Null[int]{Valid: true}, want: false},
+		{name: "json null", v: JSON[[]int]{}, want: true},
+		{name: "json valid zero", v: JSON[[]int]{Valid: true}, want: false},
+		{name: "optional absent", v: Optional[string]{}, want: true},
+		{name: "optional null", v: Optional[string]{Present: true}, want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.v.IsZero(); got != tt.want {
+			t.Errorf("%s: IsZero() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSizedNumbers_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       json.Unmarshaler
+		source  []byte
+		wantErr bool
+	}{
+		{name: "int32 valid", n: &Int32{}, source: []byte(`-2147483648`)},
+		{name: "int32 overflow", n: &Int32{}, source: []byte(`2147483648`), wantErr: true},
+		{name: "int16 valid", n: &Int16{}, source: []byte(`32767`)},
+		{name: "int16 overflow", n: &Int16{}, source: []byte(`32768`), wantErr: true},
+		{name: "int8 valid", n: &Int8{}, source: []byte(`-128`)},
+		{name: "int8 overflow", n: &Int8{}, source: []byte(`128`), wantErr: true},
+		{name: "uint64 valid", n: &Uint64{}, source: []byte(`18446744073709551615`)},
+		{name: "uint64 negative", n: &Uint64{}, source: []byte(`-1`), wantErr: true},
+		{name: "uint32 valid", n: &Uint32{}, source: []byte(`4294967295`)},
+		{name: "uint32 overflow", n: &Uint32{}, source: []byte(`4294967296`), wantErr: true},
+		{name: "uint16 valid", n: &Uint16{}, source: []byte(`65535`)},
+		{name: "uint16 overflow", n: &Uint16{}, source: []byte(`65536`), wantErr: true},
+		{name: "byte valid", n: &Byte{}, source: []byte(`255`)},
+		{name: "byte overflow", n: &Byte{}, source: []byte(`256`), wantErr: true},
+		{name: "float32 valid", n: &Float32{}, source: []byte(`1.5`)},
+		{name: "float32 overflow", n: &Float32{}, source: []byte(`1e39`), wantErr: true},
+		{name: "explicit null", n: &Int8{}, source: []byte(`null`)},
+		{name: "invalid", n: &Int16{}, source: []byte(`"12"`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.n.UnmarshalJSON(tt.source); (err != nil) != tt.wantErr {
+				t.Errorf("%T.UnmarshalJSON() error = %v, wantErr %v", tt.n, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSizedNumbers_Scan(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         interface{ Scan(any) error }
+		src       any
+		wantErr   bool
+		wantValid bool
+	}{
+		{name: "int32 valid", n: &Int32{}, src: int64(-2147483648), wantValid: true},
+		{name: "int32 overflow", n: &Int32{}, src: int64(2147483648), wantErr: true},
+		{name: "int16 valid", n: &Int16{}, src: []byte("32767"), wantValid: true},
+		{name: "int16 overflow", n: &Int16{}, src: int64(32768), wantErr: true},
+		{name: "int8 valid", n: &Int8{}, src: int64(-128), wantValid: true},
+		{name: "int8 overflow", n: &Int8{}, src: int64(-129), wantErr: true},
+		{name: "uint64 valid", n: &Uint64{}, src: "18446744073709551615", wantValid: true},
+		{name: "uint64 negative", n: &Uint64{}, src: int64(-1), wantErr: true},
+		{name: "uint32 overflow", n: &Uint32{}, src: int64(4294967296), wantErr: true},
+		{name: "uint16 overflow", n: &Uint16{}, src: int64(65536), wantErr: true},
+		{name: "byte valid", n: &Byte{}, src: int64(255), wantValid: true},
+		{name: "byte overflow", n: &Byte{}, src: int64(256), wantErr: true},
+		{name: "float32 valid", n: &Float32{}, src: float64(1.5), wantValid: true},
+		{name: "float32 overflow", n: &Float32{}, src: float64(1e39), wantErr: true},
+		{name: "nil value", n: &Int32{Int32: 1, Valid: true}, src: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.n.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("%T.Scan() error = %v, wantErr %v", tt.n, err, tt.wantErr)
+			}
+			if valid := reflect.ValueOf(tt.n).Elem().FieldByName("Valid").Bool(); valid != tt.wantValid {
+				t.Errorf("%T.Scan() valid = %v, want %v", tt.n, valid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestSizedNumbers_Value(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       driver.Valuer
+		want    driver.Value
+		wantErr bool
+	}{
+		{name: "int32", n: Int32{Int32: -5, Valid: true}, want: int64(-5)},
+		{name: "int16", n: Int16{Int16: 5, Valid: true}, want: int64(5)},
+		{name: "int8", n: Int8{Int8: 5, Valid: true}, want: int64(5)},
+		{name: "uint64", n: Uint64{Uint64: 5, Valid: true}, want: int64(5)},
+		{name: "uint64 overflow", n: Uint64{Uint64: 1 << 63, Valid: true}, wantErr: true},
+		{name: "uint32", n: Uint32{Uint32: 5, Valid: true}, want: int64(5)},
+		{name: "uint16", n: Uint16{Uint16: 5, Valid: true}, want: int64(5)},
+		{name: "byte", n: Byte{Uint8: 5, Valid: true}, want: int64(5)},
+		{name: "float32", n: Float32{Float32: 0.1, Valid: true}, want: float64(0.1)},
+		{name: "invalid", n: Int32{Int32: 5}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.Value()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%T.Value() error = %v, wantErr %v", tt.n, err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%T.Value() = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToNullInt32(t *testing.T) {
+	b := int32(123)
+	bb := MakeInt32(&b)
+	if !bb.Valid {
+		t.Errorf("expected valid, got %v", bb.Valid)
+	}
+	if bb.Int32 != 123 {
+		t.Errorf("expected 123, got %v", bb.Int32)
+	}
+
+	var b2 *uint16
+	bb2 := MakeUint16(b2)
+	if bb2.Valid {
+		t.Errorf("expected not valid, got %v", bb2.Valid)
+	}
+	if bb2.Uint16 != 0 {
+		t.Errorf("expected 0, got %v", bb2.Uint16)
+	}
+}
+
+func TestTime_Layouts(t *testing.T) {
+	layouts, layout := TimeLayouts, TimeLayout
+	t.Cleanup(func() { TimeLayouts, TimeLayout = layouts, layout })
+
+	TimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05.999999999", time.DateTime, LayoutUnix, LayoutUnixMilli}
+	want := time.Date(2017, 11, 24, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		source  []byte
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "rfc3339", source: []byte(`"2017-11-24T10:30:00Z"`), want: want},
+		{name: "nano without zone", source: []byte(`"2017-11-24T10:30:00.000000"`), want: want},
+		{name: "date time", source: []byte(`"2017-11-24 10:30:00"`), want: want},
+		{name: "epoch seconds", source: []byte(`1511519400`), want: want},
+		{name: "epoch millis", source: []byte(`1511519400000`), want: want},
+		{name: "unknown layout", source: []byte(`"24/11/2017"`), wantErr: true},
+		{name: "fractional epoch", source: []byte(`1511519400.5`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Time
+			err := n.UnmarshalJSON(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Time.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n.Valid != !tt.wantErr {
+				t.Fatalf("Time.UnmarshalJSON() valid = %v", n.Valid)
+			}
+			if !n.Time.Equal(tt.want) {
+				t.Errorf("Time.UnmarshalJSON() = %v, want %v", n.Time, tt.want)
+			}
+		})
+	}
+
+	t.Run("numbers rejected by default", func(t *testing.T) {
+		TimeLayouts = layouts
+
+		var n Time
+		if err := n.UnmarshalJSON([]byte(`1511519400`)); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+
+	t.Run("marshal layout", func(t *testing.T) {
+		TimeLayout = time.DateTime
+
+		b, err := Time{Time: want, Valid: true}.MarshalJSON()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(b) != `"2017-11-24 10:30:00"` {
+			t.Fatalf("unexpected value: %s", b)
+		}
+
+		TimeLayout = LayoutUnixMilli
+
+		b, err = Time{Time: want, Valid: true}.MarshalJSON()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(b) != `1511519400000` {
+			t.Fatalf("unexpected value: %s", b)
+		}
+	})
+}
+
+type dateTimeFormat struct{}
+
+func (dateTimeFormat) Layouts() []string { return []string{time.DateTime, LayoutUnix} }
+func (dateTimeFormat) Layout() string    { return time.DateTime }
+
+func TestFormattedTime(t *testing.T) {
+	want := time.Date(2017, 11, 24, 10, 30, 0, 0, time.UTC)
+	type event struct {
+		At FormattedTime[dateTimeFormat] `json:"at"`
+	}
+
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		var e event
+		if err := json.Unmarshal([]byte(`{"at":"2017-11-24 10:30:00"}`), &e); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !e.At.Valid || !e.At.Time.Equal(want) {
+			t.Fatalf("unexpected value: %+v", e.At)
+		}
+
+		if err := json.Unmarshal([]byte(`{"at":1511519400}`), &e); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !e.At.Valid || !e.At.Time.Equal(want) {
+			t.Fatalf("unexpected value: %+v", e.At)
+		}
+
+		if err := json.Unmarshal([]byte(`{"at":"2017-11-24T10:30:00Z"}`), &e); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		b, err := json.Marshal([]event{{At: FormattedTime[dateTimeFormat]{Time: want, Valid: true}}, {}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `[{"at":"2017-11-24 10:30:00"},{"at":null}]`
+		if string(b) != exp {
+			t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+		}
+	})
+
+	t.Run("DefaultTimeFormat", func(t *testing.T) {
+		var n FormattedTime[DefaultTimeFormat]
+		if err := n.UnmarshalJSON([]byte(`"2017-11-24T10:30:00Z"`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !n.Valid || !n.Time.Equal(want) {
+			t.Fatalf("unexpected value: %+v", n)
+		}
+	})
+}
+
+func TestDate_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  []byte
+		want    Date
+		wantErr bool
+	}{
+		{name: "valid", source: []byte(`"2017-11-24"`), want: Date{Year: 2017, Month: time.November, Day: 24, Valid: true}},
+		{name: "explicit null", source: []byte(`null`)},
+		{name: "timestamp", source: []byte(`"2017-11-24T00:00:00Z"`), wantErr: true},
+		{name: "invalid", source: []byte(`20171124`), wantErr: true},
+		{name: "empty", source: []byte{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Date
+			if err := n.UnmarshalJSON(tt.source); (err != nil) != tt.wantErr {
+				t.Errorf("Date.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n != tt.want {
+				t.Errorf("Date.UnmarshalJSON() = %+v, want %+v", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Date
+		want []byte
+	}{
+		{name: "valid", n: Date{Year: 2017, Month: time.November, Day: 4, Valid: true}, want: []byte(`"2017-11-04"`)},
+		{name: "valid null", n: Date{Year: 2017, Month: time.November, Day: 4}, want: []byte(`null`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.MarshalJSON()
+			if err != nil {
+				t.Fatalf("Date.MarshalJSON() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date.MarshalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_Scan(t *testing.T) {
+	want := Date{Year: 2017, Month: time.November, Day: 24, Valid: true}
+	tests := []struct {
+		name    string
+		src     any
+		want    Date
+		wantErr bool
+	}{
+		{name: "time", src: time.Date(2017, 11, 24, 0, 0, 0, 0, time.UTC), want: want},
+		{name: "time in location", src: time.Date(2017, 11, 24, 0, 0, 0, 0, time.FixedZone("UTC+9", 9*3600)), want: want},
+		{name: "string", src: "2017-11-24", want: want},
+		{name: "bytes", src: []byte("2017-11-24"), want: want},
+		{name: "timestamp string", src: "2017-11-24 00:00:00", want: want},
+		{name: "nil value", src: nil},
+		{name: "invalid string", src: "24/11/2017", wantErr: true},
+		{name: "invalid type", src: int64(20171124), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := Date{Year: 1, Month: 1, Day: 1, Valid: true}
+			if err := n.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("Date.Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n.Valid != tt.want.Valid || (n.Valid && n != tt.want) {
+				t.Errorf("Date.Scan() = %+v, want %+v", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_Value(t *testing.T) {
+	got, err := Date{Year: 2017, Month: time.February, Day: 3, Valid: true}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "2017-02-03" {
+		t.Errorf("Date.Value() = %v", got)
+	}
+
+	got, err = Date{}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Date.Value() = %v", got)
+	}
+}
+
+func TestDate_Time(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*3600)
+	tim := Time{Time: time.Date(2017, 11, 24, 2, 0, 0, 0, time.UTC), Valid: true}
+
+	d := tim.DateIn(loc)
+	if d != (Date{Year: 2017, Month: time.November, Day: 23, Valid: true}) {
+		t.Fatalf("unexpected date: %+v", d)
+	}
+
+	back := d.In(loc)
+	if !back.Valid || !back.Time.Equal(time.Date(2017, 11, 23, 0, 0, 0, 0, loc)) {
+		t.Fatalf("unexpected time: %+v", back)
+	}
+
+	if (Time{}).DateIn(loc).Valid || (Date{}).In(loc).Valid {
+		t.Fatalf("expected null to stay null")
+	}
+
+	if MakeDate(time.Time{}).Valid || MakeDate(tim.Time) != (Date{Year: 2017, Month: time.November, Day: 24, Valid: true}) {
+		t.Fatalf("unexpected MakeDate result")
+	}
+}
+
+func TestTimeOfDay_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  []byte
+		want    TimeOfDay
+		wantErr bool
+	}{
+		{name: "millis", source: []byte(`"15:04:05.123"`), want: TimeOfDay{Hour: 15, Minute: 4, Second: 5, Nanosecond: 123000000, Valid: true}},
+		{name: "seconds", source: []byte(`"15:04:05"`), want: TimeOfDay{Hour: 15, Minute: 4, Second: 5, Valid: true}},
+		{name: "minutes", source: []byte(`"15:04"`), want: TimeOfDay{Hour: 15, Minute: 4, Valid: true}},
+		{name: "explicit null", source: []byte(`null`)},
+		{name: "out of range", source: []byte(`"25:00:00"`), wantErr: true},
+		{name: "invalid", source: []byte(`1504`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n TimeOfDay
+			if err := n.UnmarshalJSON(tt.source); (err != nil) != tt.wantErr {
+				t.Errorf("TimeOfDay.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n != tt.want {
+				t.Errorf("TimeOfDay.UnmarshalJSON() = %+v, want %+v", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeOfDay_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal([]TimeOfDay{{Hour: 9, Minute: 30, Nanosecond: 5e6, Valid: true}, {}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := `["09:30:00.005",null]`
+	if string(b) != exp {
+		t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+	}
+}
+
+func TestTimeOfDay_Scan(t *testing.T) {
+	want := TimeOfDay{Hour: 15, Minute: 4, Second: 5, Valid: true}
+	tests := []struct {
+		name    string
+		src     any
+		want    TimeOfDay
+		wantErr bool
+	}{
+		{name: "string", src: "15:04:05", want: want},
+		{name: "bytes", src: []byte("15:04:05"), want: want},
+		{name: "time", src: time.Date(2017, 11, 24, 15, 4, 5, 0, time.UTC), want: want},
+		{name: "seconds", src: int64(15*3600 + 4*60 + 5), want: want},
+		{name: "nil value", src: nil},
+		{name: "seconds out of range", src: int64(24 * 3600), wantErr: true},
+		{name: "invalid type", src: 1.5, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := TimeOfDay{Hour: 1, Valid: true}
+			if err := n.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("TimeOfDay.Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n.Valid != tt.want.Valid || (n.Valid && n != tt.want) {
+				t.Errorf("TimeOfDay.Scan() = %+v, want %+v", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeOfDay_Value(t *testing.T) {
+	got, err := TimeOfDay{Hour: 15, Minute: 4, Second: 5, Nanosecond: 500000, Valid: true}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "15:04:05.0005" {
+		t.Errorf("TimeOfDay.Value() = %v", got)
+	}
+
+	if got, _ := (TimeOfDay{}).Value(); got != nil {
+		t.Errorf("TimeOfDay.Value() = %v", got)
+	}
+}
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  []byte
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "go", source: []byte(`"1h30m"`), want: 90 * time.Minute},
+		{name: "iso", source: []byte(`"PT1H30M"`), want: 90 * time.Minute},
+		{name: "iso days", source: []byte(`"P1DT0.5S"`), want: 24*time.Hour + 500*time.Millisecond},
+		{name: "iso negative", source: []byte(`"-PT1M"`), want: -time.Minute},
+		{name: "iso months", source: []byte(`"P1M"`), wantErr: true},
+		{name: "iso largest", source: []byte(`"P106751D"`), want: 106751 * 24 * time.Hour},
+		{name: "iso out of range", source: []byte(`"P1000000D"`), wantErr: true},
+		{name: "iso sum out of range", source: []byte(`"P106751DT24H"`), wantErr: true},
+		{name: "iso infinite", source: []byte(`"PTInfS"`), wantErr: true},
+		{name: "explicit null", source: []byte(`null`)},
+		{name: "number", source: []byte(`1000`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Duration
+			err := n.UnmarshalJSON(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Duration.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n.Valid && n.Duration != tt.want {
+				t.Errorf("Duration.UnmarshalJSON() = %v, want %v", n.Duration, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal([]Duration{{Duration: 90 * time.Minute, Valid: true}, {}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := `["1h30m0s",null]`
+	if string(b) != exp {
+		t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+	}
+}
+
+func TestDuration_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    Duration
+		wantErr bool
+	}{
+		{name: "millis", src: int64(1500), want: Duration{Duration: 1500 * time.Millisecond, Valid: true}},
+		{name: "millis largest", src: int64(math.MaxInt64 / time.Millisecond), want: Duration{Duration: math.MaxInt64 / time.Millisecond * time.Millisecond, Valid: true}},
+		{name: "millis out of range", src: int64(math.MaxInt64/time.Millisecond + 1), wantErr: true},
+		{name: "millis negative out of range", src: int64(math.MinInt64/time.Millisecond - 1), wantErr: true},
+		{name: "postgres clock", src: []byte("01:30:00"), want: Duration{Duration: 90 * time.Minute, Valid: true}},
+		{name: "postgres days", src: "1 day 02:00:00.5", want: Duration{Duration: 26*time.Hour + 500*time.Millisecond, Valid: true}},
+		{name: "postgres negative", src: "-2 days +01:00:00", want: Duration{Duration: -47 * time.Hour, Valid: true}},
+		{name: "postgres units", src: "3 hours 15 mins", want: Duration{Duration: 3*time.Hour + 15*time.Minute, Valid: true}},
+		{name: "iso", src: "PT-1H-30M", want: Duration{Duration: -90 * time.Minute, Valid: true}},
+		{name: "go", src: "1m30s", want: Duration{Duration: 90 * time.Second, Valid: true}},
+		{name: "nil value", src: nil},
+		{name: "months", src: "1 mon 2 days", wantErr: true},
+		{name: "out of range", src: "1000000 days", wantErr: true},
+		{name: "sum out of range", src: "106751 days 24:00:00", wantErr: true},
+		{name: "clock out of range", src: "3000000:00:00", wantErr: true},
+		{name: "time", src: time.Now(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := Duration{Duration: time.Second, Valid: true}
+			if err := n.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("Duration.Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n.Valid != tt.want.Valid || (n.Valid && n != tt.want) {
+				t.Errorf("Duration.Scan() = %+v, want %+v", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Duration
+		v    driver.Valuer
+		want driver.Value
+	}{
+		{name: "iso", n: Duration{Duration: 90*time.Minute + 1500*time.Millisecond, Valid: true}, want: "PT1H30M1.5S"},
+		{name: "iso zero", n: Duration{Valid: true}, want: "PT0S"},
+		{name: "iso negative", n: Duration{Duration: -time.Hour - time.Second, Valid: true}, want: "PT-1H-1S"},
+		{name: "millis", v: Duration{Duration: 1500 * time.Millisecond, Valid: true}.Millis(), want: int64(1500)},
+		{name: "millis invalid", v: Duration{Duration: time.Hour}.Millis(), want: nil},
+		{name: "invalid", n: Duration{Duration: time.Hour}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.v
+			if v == nil {
+				v = tt.n
+			}
+			got, err := v.Value()
+			if err != nil {
+				t.Fatalf("Duration.Value() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Duration.Value() = %v, want %v", got, tt.want)
+			}
+
+			if s, ok := got.(string); ok {
+				d, err := parseDuration(s)
+				if err != nil || d != tt.n.Duration {
+					t.Errorf("round trip = %v, %v", d, err)
+				}
+			}
+		})
+	}
+}
+
+func TestUUID_Parse(t *testing.T) {
+	want := UUID{UUID: [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, Valid: true}
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{
+		{name: "canonical", source: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{name: "upper case", source: "6BA7B810-9DAD-11D1-80B4-00C04FD430C8"},
+		{name: "braced", source: "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"},
+		{name: "urn", source: "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{name: "unhyphenated", source: "6ba7b8109dad11d180b400c04fd430c8"},
+		{name: "misplaced hyphen", source: "6ba7b810-9dad11d1-80b4-00c04fd430c8-", wantErr: true},
+		{name: "not hex", source: "zba7b810-9dad-11d1-80b4-00c04fd430c8", wantErr: true},
+		{name: "too short", source: "6ba7b810", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n UUID
+			if err := n.UnmarshalText([]byte(tt.source)); (err != nil) != tt.wantErr {
+				t.Fatalf("UUID.UnmarshalText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && n != want {
+				t.Errorf("UUID.UnmarshalText() = %v, want %v", n, want)
+			}
+		})
+	}
+}
+
+func TestUUID_JSON(t *testing.T) {
+	type row struct {
+		ID       UUID `json:"id"`
+		ParentID UUID `json:"parent_id"`
+	}
+	source := []byte(`{"id":"{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}","parent_id":null}`)
+
+	var r row
+	if err := json.Unmarshal(source, &r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !r.ID.Valid || r.ParentID.Valid {
+		t.Fatalf("unexpected value: %+v", r)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","parent_id":null}`
+	if string(b) != exp {
+		t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":123}`), &r); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestUUID_Scan(t *testing.T) {
+	want := UUID{UUID: [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, Valid: true}
+	tests := []struct {
+		name    string
+		src     any
+		want    UUID
+		wantErr bool
+	}{
+		{name: "string", src: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", want: want},
+		{name: "text bytes", src: []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), want: want},
+		{name: "binary", src: want.UUID[:], want: want},
+		{name: "nil value", src: nil},
+		{name: "invalid bytes", src: []byte{1, 2, 3}, wantErr: true},
+		{name: "empty string", src: "", wantErr: true},
+		{name: "empty bytes", src: []byte{}, wantErr: true},
+		{name: "invalid type", src: int64(1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := UUID{UUID: [16]byte{1}, Valid: true}
+			if err := n.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("UUID.Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n.Valid != tt.want.Valid || (n.Valid && n != tt.want) {
+				t.Errorf("UUID.Scan() = %v, want %v", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestUUID_Value(t *testing.T) {
+	n, err := ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := n.Value()
+	if err != nil || got != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("UUID.Value() = %v, %v", got, err)
+	}
+
+	got, err = n.Binary().Value()
+	if err != nil || !reflect.DeepEqual(got, n.UUID[:]) {
+		t.Errorf("UUID.Binary().Value() = %v, %v", got, err)
+	}
+	var u UUID
+	if err := u.Scan(got); err != nil || u != n {
+		t.Errorf("UUID.Scan(UUID.Binary()) = %v, %v", u, err)
+	}
+
+	got, err = UUID{}.Value()
+	if err != nil || got != nil {
+		t.Errorf("UUID.Value() = %v, %v", got, err)
+	}
+	got, err = UUID{}.Binary().Value()
+	if err != nil || got != nil {
+		t.Errorf("UUID.Binary().Value() = %v, %v", got, err)
+	}
+}
+
+func TestDecimal_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    string
+		wantErr bool
+	}{
+		{name: "integer", source: "123", want: "123"},
+		{name: "trailing zeros", source: "12.3400", want: "12.3400"},
+		{name: "negative fraction", source: "-0.05", want: "-0.05"},
+		{name: "leading dot", source: ".5", want: "0.5"},
+		{name: "explicit plus", source: "+1.0", want: "1.0"},
+		{name: "positive exponent", source: "1.5e3", want: "1500"},
+		{name: "negative exponent", source: "15E-3", want: "0.015"},
+		{name: "large", source: "123456789012345678901234567890.1234", want: "123456789012345678901234567890.1234"},
+		{name: "large negative fraction", source: "-0.000012345678901234567890123", want: "-0.000012345678901234567890123"},
+		{name: "zero fraction", source: "0.00", want: "0.00"},
+		{name: "empty", source: "", wantErr: true},
+		{name: "sign only", source: "-", wantErr: true},
+		{name: "letters", source: "12a", wantErr: true},
+		{name: "huge exponent", source: "1e999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDecimal(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDecimal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if d.String() != tt.want {
+				t.Errorf("ParseDecimal() = %q, want %q", d.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	type invoice struct {
+		Total    Decimal `json:"total"`
+		Discount Decimal `json:"discount"`
+		Tax      Decimal `json:"tax"`
+	}
+
+	var i invoice
+	if err := json.Unmarshal([]byte(`{"total":1234567890123456789.1200,"discount":null,"tax":"0.20"}`), &i); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if i.Discount.Valid || !i.Tax.Valid {
+		t.Fatalf("unexpected value: %+v", i)
+	}
+
+	b, err := json.Marshal(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := `{"total":1234567890123456789.1200,"discount":null,"tax":0.20}`
+	if string(b) != exp {
+		t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+	}
+
+	if err := json.Unmarshal([]byte(`{"total":true}`), &i); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    string
+		wantErr bool
+	}{
+		{name: "string", src: "19.9900", want: "19.9900"},
+		{name: "bytes", src: []byte("-4.5"), want: "-4.5"},
+		{name: "int64", src: int64(42), want: "42"},
+		{name: "float64", src: 0.1, want: "0.1"},
+		{name: "nil value", src: nil, want: ""},
+		{name: "invalid", src: "abc", wantErr: true},
+		{name: "invalid type", src: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NewDecimal(1, 0)
+			if err := n.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Fatalf("Decimal.Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n.String() != tt.want {
+				t.Errorf("Decimal.Scan() = %q, want %q", n.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Value(t *testing.T) {
+	got, err := NewDecimal(-1995, 2).Value()
+	if err != nil || got != "-19.95" {
+		t.Errorf("Decimal.Value() = %v, %v", got, err)
+	}
+
+	got, err = Decimal{}.Value()
+	if err != nil || got != nil {
+		t.Errorf("Decimal.Value() = %v, %v", got, err)
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	d := func(s string) Decimal {
+		t.Helper()
+		v, err := ParseDecimal(s)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return v
+	}
+	null := Decimal{}
+
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{name: "add", got: d("0.1").Add(d("0.20")), want: "0.30"},
+		{name: "sub", got: d("1").Sub(d("0.0001")), want: "0.9999"},
+		{name: "mul", got: d("1.10").Mul(d("-3")), want: "-3.30"},
+		{name: "neg", got: d("1.10").Neg(), want: "-1.10"},
+		{name: "abs", got: d("-1.10").Abs(), want: "1.10"},
+		{name: "round half up", got: d("2.345").Round(2), want: "2.35"},
+		{name: "round half away from zero", got: d("-2.345").Round(2), want: "-2.35"},
+		{name: "round down", got: d("2.344").Round(2), want: "2.34"},
+		{name: "round noop", got: d("2.3").Round(2), want: "2.3"},
+		{name: "add null", got: d("1").Add(null), want: ""},
+		{name: "mul null", got: null.Mul(d("1")), want: ""},
+		{name: "neg null", got: null.Neg(), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.String() != tt.want {
+				t.Errorf("got %q, want %q", tt.got.String(), tt.want)
+			}
+		})
+	}
+
+	t.Run("quo", func(t *testing.T) {
+		q, err := d("10").Quo(d("3"), 4)
+		if err != nil || q.String() != "3.3333" {
+			t.Fatalf("Decimal.Quo() = %q, %v", q.String(), err)
+		}
+
+		q, err = d("-2.00").Quo(d("0.3"), 2)
+		if err != nil || q.String() != "-6.67" {
+			t.Fatalf("Decimal.Quo() = %q, %v", q.String(), err)
+		}
+
+		if _, err := d("1").Quo(d("0.00"), 2); err != ErrDivisionByZero {
+			t.Fatalf("expected division by zero, got %v", err)
+		}
+
+		q, err = null.Quo(d("0"), 2)
+		if err != nil || q.Valid {
+			t.Fatalf("Decimal.Quo() = %+v, %v", q, err)
+		}
+
+		if q, err := d("1").Quo(d("0.00").NullIf(d("0")), 2); err != nil || q.Valid {
+			t.Fatalf("Decimal.Quo(NullIf()) = %+v, %v", q, err)
+		}
+		if q, err := d("10").Quo(d("4").NullIf(d("0")), 1); err != nil || q.String() != "2.5" {
+			t.Fatalf("Decimal.Quo(NullIf()) = %q, %v", q.String(), err)
+		}
+	})
+
+	t.Run("cmp", func(t *testing.T) {
+		if cmp, ok := d("1.50").Cmp(d("1.5")); !ok || cmp != 0 {
+			t.Fatalf("Decimal.Cmp() = %d, %v", cmp, ok)
+		}
+		if cmp, ok := d("-1").Cmp(d("0.001")); !ok || cmp != -1 {
+			t.Fatalf("Decimal.Cmp() = %d, %v", cmp, ok)
+		}
+		if _, ok := d("1").Cmp(null); ok {
+			t.Fatalf("expected comparison with null to be unknown")
+		}
+		if !d("1.50").Equal(d("1.5")) || d("1").Equal(null) || !null.Equal(Decimal{}) {
+			t.Fatalf("unexpected Decimal.Equal() result")
+		}
+		if sign, ok := d("-0.1").Sign(); !ok || sign != -1 {
+			t.Fatalf("Decimal.Sign() = %d, %v", sign, ok)
+		}
+	})
+}
+
+func TestBytes_JSON(t *testing.T) {
+	raw := []byte{0xfb, 0xff}
+	tests := []struct {
+		name string
+		n    json.Marshaler
+		want string
+	}{
+		{name: "base64", n: Bytes{Bytes: raw, Valid: true}, want: `"+/8="`},
+		{name: "explicit base64", n: BytesAs[Base64Encoding]{Bytes: raw, Valid: true}, want: `"+/8="`},
+		{name: "base64url", n: BytesAs[Base64URLEncoding]{Bytes: raw, Valid: true}, want: `"-_8="`},
+		{name: "hex", n: BytesAs[HexEncoding]{Bytes: raw, Valid: true}, want: `"fbff"`},
+		{name: "empty", n: Bytes{Bytes: []byte{}, Valid: true}, want: `""`},
+		{name: "hex empty", n: BytesAs[HexEncoding]{Bytes: []byte{}, Valid: true}, want: `""`},
+		{name: "null", n: Bytes{}, want: `null`},
+		{name: "hex null", n: BytesAs[HexEncoding]{}, want: `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.MarshalJSON()
+			if err != nil {
+				t.Fatalf("%T.MarshalJSON() error = %v", tt.n, err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("%T.MarshalJSON() = %s, want %s", tt.n, got, tt.want)
+			}
+
+			back := reflect.New(reflect.TypeOf(tt.n))
+			if err := json.Unmarshal(got, back.Interface()); err != nil {
+				t.Fatalf("%T.UnmarshalJSON() error = %v", tt.n, err)
+			}
+			if !reflect.DeepEqual(back.Elem().Interface(), tt.n) {
+				t.Fatalf("%T.UnmarshalJSON() = %+v, want %+v", tt.n, back.Elem().Interface(), tt.n)
+			}
+		})
+	}
+
+	t.Run("per field", func(t *testing.T) {
+		type blob struct {
+			Data     Bytes                `json:"data"`
+			Checksum BytesAs[HexEncoding] `json:"checksum"`
+		}
+		b, err := json.Marshal(blob{
+			Data:     Bytes{Bytes: raw, Valid: true},
+			Checksum: BytesAs[HexEncoding]{Bytes: raw, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exp := `{"data":"+/8=","checksum":"fbff"}`; string(b) != exp {
+			t.Fatalf("\nexp: %s\ngot: %s", exp, b)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var n BytesAs[HexEncoding]
+		if err := n.UnmarshalJSON([]byte(`"zz"`)); err == nil || n.Valid {
+			t.Fatalf("expected error")
+		}
+		var std Bytes
+		if err := std.UnmarshalJSON([]byte(`"-_8="`)); err == nil || std.Valid {
+			t.Fatalf("expected error, as Bytes is standard base64")
+		}
+	})
+}
+
+func TestBytes_Scan(t *testing.T) {
+	buf := []byte("hello")
+
+	var n Bytes
+	if err := n.Scan(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buf[0] = 'j'
+	if !n.Valid || string(n.Bytes) != "hello" {
+		t.Fatalf("expected a copy of the driver buffer, got %q", n.Bytes)
+	}
+
+	if err := n.Scan([]byte{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !n.Valid || n.Bytes == nil {
+		t.Fatalf("expected a valid empty value, got %+v", n)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n.Valid || n.Bytes != nil {
+		t.Fatalf("expected null, got %+v", n)
+	}
+
+	if err := n.Scan(int64(1)); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestBytes_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Bytes
+		want driver.Value
+	}{
+		{name: "valid", n: Bytes{Bytes: []byte("a"), Valid: true}, want: []byte("a")},
+		{name: "empty", n: Bytes{Valid: true}, want: []byte{}},
+		{name: "null", n: Bytes{Bytes: []byte("a")}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.Value()
+			if err != nil {
+				t.Fatalf("Bytes.Value() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Bytes.Value() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawJSON_Scan(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      any
+		want     RawJSON
+		wantNull bool
+		wantErr  bool
+	}{
+		{name: "object", src: []byte(`{"a":1}`), want: RawJSON(`{"a":1}`)},
+		{name: "string", src: `[1,2]`, want: RawJSON(`[1,2]`)},
+		{name: "json null", src: []byte(`null`), want: RawJSON(`null`)},
+		{name: "sql null", src: nil, wantNull: true},
+		{name: "invalid", src: []byte(`{`), wantErr: true},
+		{name: "invalid type", src: int64(1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n RawJSON
+			if err := n.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Fatalf("RawJSON.Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n.IsNull() != (tt.wantNull || tt.wantErr) {
+				t.Fatalf("RawJSON.IsNull() = %v", n.IsNull())
+			}
+			if !bytes.Equal(n, tt.want) {
+				t.Fatalf("RawJSON.Scan() = %s, want %s", n, tt.want)
+			}
+		})
+	}
+
+	t.Run("copies source", func(t *testing.T) {
+		buf := []byte(`[1]`)
+
+		var n RawJSON
+		if err := n.Scan(buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		buf[1] = '2'
+		if string(n) != `[1]` {
+			t.Fatalf("expected a copy of the driver buffer, got %s", n)
+		}
+	})
+}
+
+func TestRawJSON_Value(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       RawJSON
+		want    driver.Value
+		wantErr bool
+	}{
+		{name: "object", n: RawJSON(`{"a":1}`), want: `{"a":1}`},
+		{name: "json null", n: RawJSON(`null`), want: `null`},
+		{name: "empty", n: RawJSON{}, want: nil},
+		{name: "nil", n: nil, want: nil},
+		{name: "invalid", n: RawJSON(`{"a":`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.Value()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RawJSON.Value() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RawJSON.Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawJSON_Compact(t *testing.T) {
+	n := RawJSON("{ \"a\" : [1, 2],\n \"b\": null }")
+	got, err := n.Compact()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != `{"a":[1,2],"b":null}` {
+		t.Fatalf("RawJSON.Compact() = %s", got)
+	}
+
+	if _, err := RawJSON(`{`).Compact(); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if !RawJSON(" null ").IsJSONNull() || RawJSON(nil).IsJSONNull() {
+		t.Fatalf("unexpected RawJSON.IsJSONNull() result")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type config struct {
+		Retries int      `json:"retries"`
+		Hosts   []string `json:"hosts"`
+	}
+	cfg := config{Retries: 3, Hosts: []string{"a", "b"}}
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		type row struct {
+			Config JSON[config] `json:"config"`
+			Audit  JSON[config] `json:"audit"`
+		}
+		b, err := json.Marshal(row{Config: JSON[config]{V: cfg, Valid: true}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `{"config":{"retries":3,"hosts":["a","b"]},"audit":null}`
+		if string(b) != exp {
+			t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+		}
+	})
+
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		var n JSON[config]
+		if err := n.UnmarshalJSON([]byte(`{"retries":3,"hosts":["a","b"]}`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !n.Valid || !reflect.DeepEqual(n.V, cfg) {
+			t.Fatalf("unexpected value: %+v", n)
+		}
+
+		if err := n.UnmarshalJSON([]byte(`null`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n.Valid || n.V.Retries != 0 {
+			t.Fatalf("expected null: %+v", n)
+		}
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			src       any
+			wantValid bool
+			wantErr   bool
+		}{
+			{name: "bytes", src: []byte(`{"retries":3,"hosts":["a","b"]}`), wantValid: true},
+			{name: "string", src: `{"retries":3,"hosts":["a","b"]}`, wantValid: true},
+			{name: "sql null", src: nil},
+			{name: "json null", src: []byte(`null`)},
+			{name: "invalid", src: []byte(`{"retries":"3"}`), wantErr: true},
+			{name: "invalid type", src: int64(3), wantErr: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				n := JSON[config]{V: config{Retries: 1}, Valid: true}
+				if err := n.Scan(tt.src); (err != nil) != tt.wantErr {
+					t.Fatalf("JSON.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if n.Valid != tt.wantValid {
+					t.Fatalf("JSON.Scan() valid = %v", n.Valid)
+				}
+				if n.Valid && !reflect.DeepEqual(n.V, cfg) {
+					t.Fatalf("unexpected value: %+v", n.V)
+				}
+			})
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := JSON[config]{V: cfg, Valid: true}.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v != `{"retries":3,"hosts":["a","b"]}` {
+			t.Fatalf("unexpected value: %v", v)
+		}
+
+		v, err = JSON[config]{V: cfg}.Value()
+		if err != nil || v != nil {
+			t.Fatalf("unexpected value: %v, %v", v, err)
+		}
+
+		if _, err := (JSON[func()]{Valid: true}).Value(); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+}
+
+func TestText_RoundTrip(t *testing.T) {
+	id, _ := ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	dec, _ := ParseDecimal("12.340")
+	tests := []struct {
+		name string
+		n    encoding.TextMarshaler
+		want string
+	}{
+		{name: "string", n: String{String: "hello", Valid: true}, want: "hello"},
+		{name: "int64", n: Int64{Int64: -42, Valid: true}, want: "-42"},
+		{name: "int32", n: Int32{Int32: -42, Valid: true}, want: "-42"},
+		{name: "int16", n: Int16{Int16: -42, Valid: true}, want: "-42"},
+		{name: "int8", n: Int8{Int8: -42, Valid: true}, want: "-42"},
+		{name: "uint64", n: Uint64{Uint64: 42, Valid: true}, want: "42"},
+		{name: "uint32", n: Uint32{Uint32: 42, Valid: true}, want: "42"},
+		{name: "uint16", n: Uint16{Uint16: 42, Valid: true}, want: "42"},
+		{name: "uint8", n: Uint8{Uint8: 42, Valid: true}, want: "42"},
+		{name: "float64", n: Float64{Float64: 1.5, Valid: true}, want: "1.5"},
+		{name: "float32", n: Float32{Float32: 0.1, Valid: true}, want: "0.1"},
+		{name: "bool", n: Bool{Bool: true, Valid: true}, want: "true"},
+		{name: "time", n: Time{Time: time.Date(2017, 11, 24, 10, 30, 0, 0, time.UTC), Valid: true}, want: "2017-11-24T10:30:00Z"},
+		{name: "formatted time", n: FormattedTime[dateTimeFormat]{Time: time.Date(2017, 11, 24, 10, 30, 0, 0, time.UTC), Valid: true}, want: "2017-11-24 10:30:00"},
+		{name: "date", n: Date{Year: 2017, Month: time.November, Day: 24, Valid: true}, want: "2017-11-24"},
+		{name: "time of day", n: TimeOfDay{Hour: 10, Minute: 30, Nanosecond: 1000, Valid: true}, want: "10:30:00.000001"},
+		{name: "duration", n: Duration{Duration: 90 * time.Minute, Valid: true}, want: "1h30m0s"},
+		{name: "uuid", n: id, want: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{name: "decimal", n: dec, want: "12.340"},
+		{name: "bytes", n: Bytes{Bytes: []byte("hi"), Valid: true}, want: "aGk="},
+		{name: "hex bytes", n: BytesAs[HexEncoding]{Bytes: []byte("hi"), Valid: true}, want: "6869"},
+		{name: "raw json", n: RawJSON(`{"a":1}`), want: `{"a":1}`},
+		{name: "null", n: Null[int]{V: 42, Valid: true}, want: "42"},
+		{name: "null delegating", n: Null[UUID]{V: id, Valid: true}, want: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{name: "null struct", n: Null[struct{ X, Y int }]{V: struct{ X, Y int }{1, 2}, Valid: true}, want: `{"X":1,"Y":2}`},
+		{name: "null slice", n: Null[[]string]{V: []string{"a", "b"}, Valid: true}, want: `["a","b"]`},
+		{name: "optional", n: Optional[string]{V: "hi", Valid: true, Present: true}, want: "hi"},
+		{name: "json", n: JSON[[]int]{V: []int{1, 2}, Valid: true}, want: "[1,2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.MarshalText()
+			if err != nil {
+				t.Fatalf("%T.MarshalText() error = %v", tt.n, err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("%T.MarshalText() = %q, want %q", tt.n, got, tt.want)
+			}
+			if got, err := tt.n.(textAppender).AppendText([]byte("prefix:")); err != nil || string(got) != "prefix:"+tt.want {
+				t.Fatalf("%T.AppendText() = %q, %v, want %q", tt.n, got, err, "prefix:"+tt.want)
+			}
+
+			back := reflect.New(reflect.TypeOf(tt.n))
+			if err := back.Interface().(encoding.TextUnmarshaler).UnmarshalText(got); err != nil {
+				t.Fatalf("%T.UnmarshalText() error = %v", tt.n, err)
+			}
+			if !reflect.DeepEqual(back.Elem().Interface(), tt.n) {
+				t.Fatalf("%T.UnmarshalText() = %+v, want %+v", tt.n, back.Elem().Interface(), tt.n)
+			}
+
+			// The zero value of every type is null, and as such empty text.
+			zero := reflect.Zero(reflect.TypeOf(tt.n)).Interface().(encoding.TextMarshaler)
+			if got, err := zero.MarshalText(); err != nil || len(got) != 0 {
+				t.Fatalf("%T.MarshalText() = %q, %v for null", tt.n, got, err)
+			}
+			if err := back.Interface().(encoding.TextUnmarshaler).UnmarshalText(nil); err != nil {
+				t.Fatalf("%T.UnmarshalText() error = %v", tt.n, err)
+			}
+			if v := back.Elem(); v.Kind() == reflect.Struct && v.FieldByName("Valid").Bool() {
+				t.Fatalf("%T.UnmarshalText() of empty text should be null", tt.n)
+			}
+		})
+	}
+}
+
+func TestText_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      encoding.TextUnmarshaler
+		source string
+	}{
+		{name: "int8 overflow", n: &Int8{}, source: "128"},
+		{name: "uint16 negative", n: &Uint16{}, source: "-1"},
+		{name: "float32 overflow", n: &Float32{}, source: "1e39"},
+		{name: "bool", n: &Bool{}, source: "yes"},
+		{name: "time", n: &Time{}, source: "yesterday"},
+		{name: "date", n: &Date{}, source: "2017-13-01"},
+		{name: "duration", n: &Duration{}, source: "forever"},
+		{name: "raw json", n: new(RawJSON), source: "{"},
+		{name: "null", n: &Null[int]{}, source: "1.5"},
+		{name: "null struct", n: &Null[struct{ X int }]{}, source: "{"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.n.UnmarshalText([]byte(tt.source)); err == nil {
+				t.Fatalf("%T.UnmarshalText() expected error", tt.n)
+			}
+		})
+	}
+}
+
+func TestText_MapKeys(t *testing.T) {
+	m := map[Int64]String{
+		{Int64: 1, Valid: true}: {String: "one", Valid: true},
+		{Int64: 2, Valid: true}: {},
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := `{"1":"one","2":null}`
+	if string(b) != exp {
+		t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+	}
+
+	var back map[Int64]String
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(back, m) {
+		t.Fatalf("unexpected value: %+v", back)
+	}
+}
+
+func TestText_Flag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	var timeout Duration
+	var limit Null[int]
+	fs.TextVar(&timeout, "timeout", Duration{}, "")
+	fs.TextVar(&limit, "limit", Null[int]{}, "")
+	if err := fs.Parse([]string{"-timeout", "PT30S"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !timeout.Valid || timeout.Duration != 30*time.Second {
+		t.Fatalf("unexpected timeout: %+v", timeout)
+	}
+	if limit.Valid {
+		t.Fatalf("unexpected limit: %+v", limit)
+	}
+}
+
+func TestXML(t *testing.T) {
+	type order struct {
+		XMLName  xml.Name  `xml:"order"`
+		ID       Int64     `xml:"id,attr"`
+		Coupon   String    `xml:"coupon,attr"`
+		Customer String    `xml:"customer"`
+		Total    Decimal   `xml:"total"`
+		Shipped  Time      `xml:"shipped"`
+		Express  Null[int] `xml:"express"`
+	}
+	total, _ := ParseDecimal("19.90")
+	o := order{
+		ID:       Int64{Int64: 7, Valid: true},
+		Customer: String{String: "John", Valid: true},
+		Total:    total,
+	}
+
+	t.Run("omit", func(t *testing.T) {
+		b, err := xml.Marshal(o)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `<order id="7"><customer>John</customer><total>19.90</total></order>`
+		if string(b) != exp {
+			t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+		}
+
+		var back order
+		if err := xml.Unmarshal(b, &back); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		back.XMLName = xml.Name{}
+		if !reflect.DeepEqual(back, o) {
+			t.Fatalf("unexpected value: %+v", back)
+		}
+	})
+
+	t.Run("xsi:nil", func(t *testing.T) {
+		type shipment struct {
+			XMLName  xml.Name          `xml:"shipment"`
+			Carrier  XMLNil[String]    `xml:"carrier,attr"`
+			Customer XMLNil[String]    `xml:"customer"`
+			Shipped  XMLNil[Time]      `xml:"shipped"`
+			Express  XMLNil[Null[int]] `xml:"express"`
+			Note     String            `xml:"note"`
+		}
+		s := shipment{Customer: XMLNil[String]{V: String{String: "John", Valid: true}}}
+
+		b, err := xml.Marshal(s)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `<shipment><customer>John</customer>` +
+			`<shipped xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></shipped>` +
+			`<express xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></express></shipment>`
+		if string(b) != exp {
+			t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+		}
+
+		back := shipment{
+			Shipped: XMLNil[Time]{V: Time{Time: time.Now(), Valid: true}},
+			Express: XMLNil[Null[int]]{V: Null[int]{V: 1, Valid: true}},
+		}
+		if err := xml.Unmarshal(b, &back); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if back.Shipped.V.Valid || back.Express.V.Valid || back.Customer != s.Customer {
+			t.Fatalf("unexpected value: %+v", back)
+		}
+
+		s.Carrier = XMLNil[String]{V: String{String: "UPS", Valid: true}}
+		if b, err = xml.Marshal(s); err != nil || !bytes.HasPrefix(b, []byte(`<shipment carrier="UPS">`)) {
+			t.Fatalf("unexpected attribute: %s, %v", b, err)
+		}
+		back = shipment{}
+		if err := xml.Unmarshal(b, &back); err != nil || back.Carrier != s.Carrier {
+			t.Fatalf("unexpected attribute: %+v, %v", back.Carrier, err)
+		}
+	})
+
+	t.Run("undeclared xsi prefix", func(t *testing.T) {
+		var back order
+		back.Customer = String{String: "Jane", Valid: true}
+		if err := xml.Unmarshal([]byte(`<order><customer xsi:nil="true"/></order>`), &back); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if back.Customer.Valid {
+			t.Fatalf("unexpected value: %+v", back.Customer)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var back order
+		if err := xml.Unmarshal([]byte(`<order id="seven"></order>`), &back); err == nil {
+			t.Fatalf("expected error")
+		}
+		if err := xml.Unmarshal([]byte(`<order><total>abc</total></order>`), &back); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		type address struct {
+			City XMLNil[String] `xml:"city"`
+			Zip  string         `xml:"zip,attr"`
+		}
+		type customer struct {
+			XMLName  xml.Name              `xml:"customer"`
+			Billing  Null[address]         `xml:"billing"`
+			Shipping Optional[address]     `xml:"shipping"`
+			Previous XMLNil[Null[address]] `xml:"previous"`
+			Phones   Null[[]String]        `xml:"phone"`
+		}
+		c := customer{
+			Billing:  Null[address]{V: address{City: XMLNil[String]{V: String{String: "Paris", Valid: true}}, Zip: "75001"}, Valid: true},
+			Shipping: Optional[address]{V: address{Zip: "10115"}, Valid: true, Present: true},
+			Phones:   Null[[]String]{V: []String{{String: "1", Valid: true}, {String: "2", Valid: true}}, Valid: true},
+		}
+
+		b, err := xml.Marshal(c)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `<customer><billing zip="75001"><city>Paris</city></billing>` +
+			`<shipping zip="10115"><city xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></city></shipping>` +
+			`<previous xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></previous>` +
+			`<phone>1</phone><phone>2</phone></customer>`
+		if string(b) != exp {
+			t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+		}
+
+		back := customer{Previous: XMLNil[Null[address]]{V: Null[address]{V: address{Zip: "1"}, Valid: true}}}
+		if err := xml.Unmarshal(b, &back); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		back.XMLName = xml.Name{}
+		if !reflect.DeepEqual(back, c) {
+			t.Fatalf("\nexp: %+v\ngot: %+v", c, back)
+		}
+	})
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		String    String
+		Int64     Int64
+		Int32     Int32
+		Int16     Int16
+		Int8      Int8
+		Uint64    Uint64
+		Uint32    Uint32
+		Uint16    Uint16
+		Uint8     Uint8
+		Float64   Float64
+		Float32   Float32
+		Bool      Bool
+		Time      Time
+		Formatted FormattedTime[DefaultTimeFormat]
+		Date      Date
+		TimeOfDay TimeOfDay
+		Duration  Duration
+		UUID      UUID
+		Decimal   Decimal
+		Bytes     Bytes
+		HexBytes  BytesAs[HexEncoding]
+		RawJSON   RawJSON
+		Tags      Null[[]string]
+		Count     Null[int]
+		Override  Optional[string]
+		Meta      JSON[map[string]int]
+	}
+	id, _ := ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	stamp := time.Date(2017, 11, 24, 10, 30, 0, 123456789, time.UTC)
+	valid := record{
+		String:    String{String: "hello", Valid: true},
+		Int64:     Int64{Int64: -1 << 40, Valid: true},
+		Int32:     Int32{Int32: -32, Valid: true},
+		Int16:     Int16{Int16: 16, Valid: true},
+		Int8:      Int8{Int8: -8, Valid: true},
+		Uint64:    Uint64{Uint64: 1 << 63, Valid: true},
+		Uint32:    Uint32{Uint32: 32, Valid: true},
+		Uint16:    Uint16{Uint16: 16, Valid: true},
+		Uint8:     Uint8{Uint8: 8, Valid: true},
+		Float64:   Float64{Float64: 1.5, Valid: true},
+		Float32:   Float32{Float32: 0.25, Valid: true},
+		Bool:      Bool{Bool: false, Valid: true},
+		Time:      Time{Time: stamp, Valid: true},
+		Formatted: FormattedTime[DefaultTimeFormat]{Time: stamp, Valid: true},
+		Date:      Date{Year: -44, Month: time.March, Day: 15, Valid: true},
+		TimeOfDay: TimeOfDay{Hour: 23, Minute: 59, Second: 59, Nanosecond: 999999999, Valid: true},
+		Duration:  Duration{Duration: -time.Minute, Valid: true},
+		UUID:      id,
+		Decimal:   NewDecimal(-123400, 4),
+		Bytes:     Bytes{Bytes: []byte{}, Valid: true},
+		HexBytes:  BytesAs[HexEncoding]{Bytes: []byte{1}, Valid: true},
+		RawJSON:   RawJSON(`{"a":1}`),
+		Tags:      Null[[]string]{V: []string{"a", "b"}, Valid: true},
+		Count:     Null[int]{V: 0, Valid: true},
+		Override:  Optional[string]{Valid: false, Present: true},
+		Meta:      JSON[map[string]int]{V: map[string]int{"a": 1}, Valid: true},
+	}
+
+	roundTrip := func(t *testing.T, in record) (record, int) {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		size := buf.Len()
+		var out record
+		if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return out, size
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		out, _ := roundTrip(t, valid)
+		if !reflect.DeepEqual(out, valid) {
+			t.Fatalf("\nexp: %+v\ngot: %+v", valid, out)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		in := record{Override: Optional[string]{Present: true}}
+		out, size := roundTrip(t, in)
+		if !reflect.DeepEqual(out, in) {
+			t.Fatalf("\nexp: %+v\ngot: %+v", in, out)
+		}
+		if _, validSize := roundTrip(t, valid); size >= validSize {
+			t.Fatalf("expected null values to be smaller, got %d bytes against %d", size, validSize)
+		}
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		tests := []struct {
+			value gob.GobEncoder
+			want  []byte
+		}{
+			{value: String{}, want: []byte{0}},
+			{value: String{String: "a", Valid: true}, want: []byte{1, 'a'}},
+			{value: Int64{Int64: -1, Valid: true}, want: []byte{1, 1}},
+			{value: Uint8{Uint8: 200, Valid: true}, want: []byte{1, 200, 1}},
+			{value: Bool{Bool: true, Valid: true}, want: []byte{1, 1}},
+			{value: Null[[]string]{}, want: []byte{0}},
+			{value: Null[int32]{V: 3, Valid: true}, want: []byte{1, 6}},
+			{value: Time{}, want: []byte{0}},
+		}
+		for _, tt := range tests {
+			got, err := tt.value.GobEncode()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Fatalf("%T: exp %v, got %v", tt.value, tt.want, got)
+			}
+		}
+	})
+
+	t.Run("locations", func(t *testing.T) {
+		zones := []*time.Location{time.Local, time.FixedZone("XYZ", 5*3600+1800)}
+		if loc, err := time.LoadLocation("America/New_York"); err == nil {
+			zones = append(zones, loc)
+		}
+		for _, loc := range zones {
+			in := Time{Time: stamp.In(loc), Valid: true}
+			b, err := in.GobEncode()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var out Time
+			if err := out.GobDecode(b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !out.Time.Equal(in.Time) || out.Time.Format(time.RFC3339Nano) != in.Time.Format(time.RFC3339Nano) {
+				t.Fatalf("%s: exp %v, got %v", loc, in.Time, out.Time)
+			}
+			if loc != zones[1] && out.Time.Location().String() != loc.String() {
+				t.Fatalf("exp location %s, got %s", loc, out.Time.Location())
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			value gob.GobDecoder
+			data  []byte
+		}{
+			{value: &String{}, data: []byte{}},
+			{value: &String{}, data: []byte{2, 'a'}},
+			{value: &Int64{}, data: []byte{0, 1}},
+			{value: &Int8{}, data: []byte{1, 0x80, 0x02}},
+			{value: &Uint8{}, data: []byte{1, 0x80, 0x02}},
+			{value: &Float64{}, data: []byte{1, 0, 0}},
+			{value: &Bool{}, data: []byte{1, 2}},
+			{value: &UUID{}, data: []byte{1, 1, 2}},
+			{value: &TimeOfDay{}, data: append([]byte{1}, binary.AppendUvarint(nil, uint64(24*time.Hour))...)},
+			{value: &RawJSON{}, data: []byte{1, '{'}},
+			{value: &Null[int8]{}, data: []byte{1, 0x80, 0x02}},
+		}
+		for _, tt := range tests {
+			if err := tt.value.GobDecode(tt.data); err == nil {
+				t.Fatalf("%T: expected an error for %v", tt.value, tt.data)
+			}
+		}
+	})
+}
+
+// encoder is implemented by every type, and measured by the benchmarks.
+type encoder interface {
+	json.Marshaler
+	encoding.BinaryMarshaler
+	binaryAppender
+	textAppender
+}
+
+func encoderValues() []struct {
+	name  string
+	value encoder
+} {
+	id, _ := ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	stamp := time.Date(2017, 11, 24, 10, 30, 0, 123456789, time.UTC)
+	return []struct {
+		name  string
+		value encoder
+	}{
+		{name: "String", value: String{String: "hello", Valid: true}},
+		{name: "Int64", value: Int64{Int64: -1 << 40, Valid: true}},
+		{name: "Int32", value: Int32{Int32: -32, Valid: true}},
+		{name: "Int16", value: Int16{Int16: 16, Valid: true}},
+		{name: "Int8", value: Int8{Int8: -8, Valid: true}},
+		{name: "Uint64", value: Uint64{Uint64: 1 << 63, Valid: true}},
+		{name: "Uint32", value: Uint32{Uint32: 32, Valid: true}},
+		{name: "Uint16", value: Uint16{Uint16: 16, Valid: true}},
+		{name: "Uint8", value: Uint8{Uint8: 8, Valid: true}},
+		{name: "Float64", value: Float64{Float64: 1.5, Valid: true}},
+		{name: "Float32", value: Float32{Float32: 0.25, Valid: true}},
+		{name: "Bool", value: Bool{Bool: true, Valid: true}},
+		{name: "Time", value: Time{Time: stamp, Valid: true}},
+		{name: "FormattedTime", value: FormattedTime[DefaultTimeFormat]{Time: stamp, Valid: true}},
+		{name: "Date", value: Date{Year: 2017, Month: time.November, Day: 24, Valid: true}},
+		{name: "TimeOfDay", value: TimeOfDay{Hour: 23, Minute: 59, Second: 59, Nanosecond: 999, Valid: true}},
+		{name: "Duration", value: Duration{Duration: -time.Minute, Valid: true}},
+		{name: "UUID", value: id},
+		{name: "Decimal", value: NewDecimal(-123400, 4)},
+		{name: "Bytes", value: Bytes{Bytes: []byte("hello"), Valid: true}},
+		{name: "BytesAs", value: BytesAs[HexEncoding]{Bytes: []byte("hello"), Valid: true}},
+		{name: "RawJSON", value: RawJSON(`{"a":1}`)},
+		{name: "Null", value: Null[int64]{V: 1 << 40, Valid: true}},
+		{name: "Optional", value: Optional[string]{V: "hello", Valid: true, Present: true}},
+		{name: "JSON", value: JSON[map[string]int]{V: map[string]int{"a": 1}, Valid: true}},
+		{name: "Null null", value: Null[int64]{}},
+		{name: "String null", value: String{}},
+	}
+}
+
+func TestBinary(t *testing.T) {
+	for _, tt := range encoderValues() {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.value.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			appended, err := tt.value.AppendBinary([]byte("prefix:"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(appended) != "prefix:"+string(b) {
+				t.Fatalf("\nexp: %q\ngot: %q", "prefix:"+string(b), appended)
+			}
+
+			back := reflect.New(reflect.TypeOf(tt.value))
+			if err := back.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(back.Elem().Interface(), tt.value) {
+				t.Fatalf("\nexp: %+v\ngot: %+v", tt.value, back.Elem().Interface())
+			}
+		})
+	}
+
+	t.Run("allocations", func(t *testing.T) {
+		// Null and Optional allocate for other types than basic kinds, and
+		// JSON as encoding/json does, so JSON is left out.
+		buf := make([]byte, 0, 64)
+		for _, tt := range encoderValues() {
+			if tt.name == "JSON" {
+				continue
+			}
+			if n := testing.AllocsPerRun(10, func() { buf, _ = tt.value.AppendBinary(buf[:0]) }); n != 0 {
+				t.Errorf("%s.AppendBinary: %v allocations", tt.name, n)
+			}
+			if n := testing.AllocsPerRun(10, func() { buf, _ = tt.value.AppendText(buf[:0]) }); n != 0 {
+				t.Errorf("%s.AppendText: %v allocations", tt.name, n)
+			}
+		}
+	})
+
+	t.Run("time zones", func(t *testing.T) {
+		stamp := time.Date(2017, 11, 24, 10, 30, 0, 123456789, time.UTC)
+		zones := []*time.Location{time.UTC, time.FixedZone("", -3600), time.FixedZone("XYZ", 5*3600+1800)}
+		for _, loc := range zones {
+			in := Time{Time: stamp.In(loc), Valid: true}
+			b, _ := in.MarshalBinary()
+			var out Time
+			if err := out.UnmarshalBinary(b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(out, in) {
+				t.Fatalf("\nexp: %v\ngot: %v", in.Time, out.Time)
+			}
+		}
+	})
+
+	t.Run("location cache", func(t *testing.T) {
+		first, ok := loadLocation("Europe/Paris")
+		if !ok {
+			t.Skip("time zone database unavailable")
+		}
+		if again, _ := loadLocation("Europe/Paris"); again != first {
+			t.Fatal("expected the cached location")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			value encoding.BinaryUnmarshaler
+			data  []byte
+		}{
+			{value: &Time{}, data: []byte{1}},
+			{value: &Time{}, data: append(binary.AppendVarint([]byte{1}, 0), binary.AppendUvarint(nil, uint64(time.Second))...)},
+			{value: &Decimal{}, data: []byte{1, 0x80}},
+			{value: &Date{}, data: []byte{1, 2}},
+		}
+		for _, tt := range tests {
+			if err := tt.value.UnmarshalBinary(tt.data); err == nil {
+				t.Fatalf("%T: expected an error for %v", tt.value, tt.data)
+			}
+		}
+	})
+}
+
+func BenchmarkAppendBinary(b *testing.B) {
+	for _, bb := range encoderValues() {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 64)
+			for i := 0; i < b.N; i++ {
+				buf, _ = bb.value.AppendBinary(buf[:0])
+			}
+		})
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	for _, bb := range encoderValues() {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 64)
+			for i := 0; i < b.N; i++ {
+				buf, _ = bb.value.AppendText(buf[:0])
+			}
+		})
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	for _, bb := range encoderValues() {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = bb.value.MarshalJSON()
+			}
+		})
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	stamp := time.Date(2017, 11, 24, 10, 30, 0, 0, time.UTC)
+	t.Run("String", func(t *testing.T) { testAccessor[string](t, &String{}, "a", "def") })
+	t.Run("Int64", func(t *testing.T) { testAccessor[int64](t, &Int64{}, -1, 7) })
+	t.Run("Float64", func(t *testing.T) { testAccessor[float64](t, &Float64{}, 1.5, 7) })
+	t.Run("Bool", func(t *testing.T) { testAccessor[bool](t, &Bool{}, false, true) })
+	t.Run("Time", func(t *testing.T) { testAccessor[time.Time](t, &Time{}, stamp, stamp.Add(time.Hour)) })
+	t.Run("Null", func(t *testing.T) { testAccessor[int](t, &Null[int]{}, 42, 7) })
+	t.Run("Int32", func(t *testing.T) { testAccessor[int32](t, &Int32{}, -1, 7) })
+	t.Run("Int16", func(t *testing.T) { testAccessor[int16](t, &Int16{}, -1, 7) })
+	t.Run("Int8", func(t *testing.T) { testAccessor[int8](t, &Int8{}, -1, 7) })
+	t.Run("Uint64", func(t *testing.T) { testAccessor[uint64](t, &Uint64{}, 1, 7) })
+	t.Run("Uint32", func(t *testing.T) { testAccessor[uint32](t, &Uint32{}, 1, 7) })
+	t.Run("Uint16", func(t *testing.T) { testAccessor[uint16](t, &Uint16{}, 1, 7) })
+	t.Run("Uint8", func(t *testing.T) { testAccessor[uint8](t, &Uint8{}, 1, 7) })
+	t.Run("Float32", func(t *testing.T) { testAccessor[float32](t, &Float32{}, 1.5, 7) })
+	t.Run("FormattedTime", func(t *testing.T) {
+		testAccessor[time.Time](t, &FormattedTime[DefaultTimeFormat]{}, stamp, stamp.Add(time.Hour))
+	})
+	t.Run("Date", func(t *testing.T) { testAccessor[time.Time](t, &Date{}, stamp.Truncate(24*time.Hour), stamp) })
+	t.Run("TimeOfDay", func(t *testing.T) { testAccessor[time.Duration](t, &TimeOfDay{}, 90*time.Minute, time.Hour) })
+	t.Run("Duration", func(t *testing.T) { testAccessor[time.Duration](t, &Duration{}, -time.Minute, time.Hour) })
+	t.Run("UUID", func(t *testing.T) { testAccessor[[16]byte](t, &UUID{}, [16]byte{1, 2}, [16]byte{3}) })
+	t.Run("JSON", func(t *testing.T) { testAccessor[int](t, &JSON[int]{}, 42, 7) })
+
+	t.Run("Bytes", func(t *testing.T) {
+		testBytesAccessor(t, &Bytes{})
+	})
+	t.Run("BytesAs", func(t *testing.T) {
+		testBytesAccessor(t, &BytesAs[HexEncoding]{})
+	})
+
+	t.Run("Decimal", func(t *testing.T) {
+		var n Decimal
+		if n.Ptr() != nil || n.ValueOrZero() != nil || n.ValueOr(big.NewRat(1, 2)).Cmp(big.NewRat(1, 2)) != 0 {
+			t.Fatalf("unexpected accessors for null %+v", n)
+		}
+		for _, tt := range []struct {
+			v    *big.Rat
+			want string
+		}{
+			{v: big.NewRat(-1234, 100), want: "-12.34"},
+			{v: big.NewRat(5, 8), want: "0.625"},
+			{v: big.NewRat(42, 1), want: "42"},
+			{v: big.NewRat(2, 3), want: "0.6666666666666666666666666666666667"},
+		} {
+			n.Set(tt.v)
+			if n.String() != tt.want {
+				t.Fatalf("Set(%v) = %q, want %q", tt.v, n.String(), tt.want)
+			}
+		}
+		n.Set(big.NewRat(5, 8))
+		if v := *n.Ptr(); v.Cmp(big.NewRat(5, 8)) != 0 {
+			t.Fatalf("Ptr() = %v", v)
+		}
+		n.Set(nil)
+		if !n.IsNull() {
+			t.Fatalf("Set(nil) should set null, got %+v", n)
+		}
+	})
+
+	t.Run("TimeOfDay wraps", func(t *testing.T) {
+		var n TimeOfDay
+		n.Set(-time.Hour)
+		if n != (TimeOfDay{Hour: 23, Valid: true}) {
+			t.Fatalf("Set(-1h) = %+v", n)
+		}
+		n.Set(25*time.Hour + time.Nanosecond)
+		if n != (TimeOfDay{Hour: 1, Nanosecond: 1, Valid: true}) {
+			t.Fatalf("Set(25h) = %+v", n)
+		}
+	})
+
+	t.Run("Ptr", func(t *testing.T) {
+		s := MakeString(nil)
+		if s.Ptr() != nil {
+			t.Fatalf("expected nil for null")
+		}
+		v := "a"
+		s = MakeString(&v)
+		p := s.Ptr()
+		if p == nil || *p != v {
+			t.Fatalf("unexpected pointer: %v", p)
+		}
+		*p = "b"
+		if s.String != "a" {
+			t.Fatalf("Ptr should point to a copy, got %q", s.String)
+		}
+		if MakeString(s.Ptr()) != s {
+			t.Fatalf("MakeString(Ptr()) should round trip")
+		}
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		// Scanning NULL leaves the previous value in place but marks it invalid.
+		n := Int64{Int64: 42}
+		if v, ok := n.Get(); v != 0 || ok {
+			t.Fatalf("Get() = %v, %v for null", v, ok)
+		}
+		if n.ValueOrZero() != 0 {
+			t.Fatalf("ValueOrZero() = %v for null", n.ValueOrZero())
+		}
+	})
+}
+
+func testAccessor[T comparable](t *testing.T, a Accessor[T], v, def T) {
+	t.Helper()
+	var zero T
+	if !a.IsNull() || a.Ptr() != nil || a.ValueOr(def) != def || a.ValueOrZero() != zero {
+		t.Fatalf("unexpected accessors for null %+v", a)
+	}
+	if got, ok := a.Get(); ok || got != zero {
+		t.Fatalf("Get() = %v, %v for null", got, ok)
+	}
+
+	a.Set(v)
+	if a.IsNull() || *a.Ptr() != v || a.ValueOr(def) != v || a.ValueOrZero() != v {
+		t.Fatalf("unexpected accessors for %+v", a)
+	}
+	if got, ok := a.Get(); !ok || got != v {
+		t.Fatalf("Get() = %v, %v, want %v", got, ok, v)
+	}
+
+	a.Clear()
+	if !a.IsNull() || a.ValueOr(def) != def {
+		t.Fatalf("unexpected accessors after Clear: %+v", a)
+	}
+	if !reflect.ValueOf(a).Elem().IsZero() {
+		t.Fatalf("Clear() should reset to the zero value, got %+v", a)
+	}
+}
+
+// testBytesAccessor checks that a valid, empty n is not null, unlike in testAccessor
+func testBytesAccessor(t *testing.T, n Accessor[[]byte]) {
+	t.Helper()
+	if n.Ptr() != nil || n.ValueOr([]byte("def")) == nil || n.ValueOrZero() != nil || !n.IsNull() {
+		t.Fatalf("unexpected accessors for null %+v", n)
+	}
+	n.Set([]byte{})
+	if n.IsNull() || n.ValueOrZero() == nil || *n.Ptr() == nil {
+		t.Fatalf("an empty value should not be null: %+v", n)
+	}
+	n.Clear()
+	if !reflect.ValueOf(n).Elem().IsZero() {
+		t.Fatalf("Clear() should reset to the zero value, got %+v", n)
+	}
+}
+
+func TestNullable(t *testing.T) {
+	t.Run("Get", func(t *testing.T) {
+		dec, _ := ParseDecimal("-12.340")
+		stamp := time.Date(2017, 11, 24, 0, 0, 0, 0, time.UTC)
+		if v, ok := (Date{Year: 2017, Month: time.November, Day: 24, Valid: true}).Get(); !ok || !v.Equal(stamp) || v.Location() != time.UTC {
+			t.Fatalf("Date.Get() = %v, %v", v, ok)
+		}
+		if v, ok := (TimeOfDay{Hour: 1, Second: 2, Nanosecond: 3, Valid: true}).Get(); !ok || v != time.Hour+2*time.Second+3 {
+			t.Fatalf("TimeOfDay.Get() = %v, %v", v, ok)
+		}
+		if v, ok := dec.Get(); !ok || v.Cmp(big.NewRat(-1234, 100)) != 0 {
+			t.Fatalf("Decimal.Get() = %v, %v", v, ok)
+		}
+		if v, ok := RawJSON(`[1]`).Get(); !ok || string(v) != "[1]" {
+			t.Fatalf("RawJSON.Get() = %s, %v", v, ok)
+		}
+		if v, ok := (Optional[int]{V: 1, Present: true}).Get(); ok || v != 0 {
+			t.Fatalf("Optional.Get() = %v, %v for null", v, ok)
+		}
+		if (Uint8{Uint8: 1}).IsValid() || !(Uint8{Valid: true}).IsValid() || RawJSON(nil).IsValid() {
+			t.Fatalf("unexpected IsValid")
+		}
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		got := Map(From(String{String: "abc", Valid: true}), func(s string) int { return len(s) })
+		if got != (Null[int]{V: 3, Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		if got := Map(From(String{}), func(s string) int { return len(s) }); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+	})
+
+	t.Run("FlatMap", func(t *testing.T) {
+		parse := func(s string) Null[int64] {
+			var n Null[int64]
+			_ = n.UnmarshalText([]byte(s))
+			return n
+		}
+		if got := FlatMap(From(String{String: "42", Valid: true}), parse); got != (Null[int64]{V: 42, Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		if got := FlatMap(From(String{String: "x", Valid: true}), parse); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+		if got := FlatMap(From(String{}), parse); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+	})
+
+	t.Run("From", func(t *testing.T) {
+		if got := From(Decimal{}); got.Valid || got.V != nil {
+			t.Fatalf("expected null, got %+v", got)
+		}
+		if got := From(Date{Year: 2017, Month: time.November, Day: 24, Valid: true}); !got.Valid || got.V.Day() != 24 {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+	})
+
+	t.Run("Or", func(t *testing.T) {
+		a, b := Int64{Int64: 1, Valid: true}, Int64{Int64: 2, Valid: true}
+		if got := Or(a, b); got != (Null[int64]{V: 1, Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		if got := Or(Int64{Int64: 3}, b); got != (Null[int64]{V: 2, Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		if got := Or(Int64{}, Null[int64]{}); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		stamp := time.Date(2017, 11, 24, 10, 30, 0, 0, time.UTC)
+		a, _ := ParseDecimal("1.50")
+		b, _ := ParseDecimal("1.5")
+		tests := []struct {
+			name string
+			got  bool
+			want bool
+		}{
+			{name: "both null", got: Equal(String{}, String{String: "stale"}), want: true},
+			{name: "one null", got: Equal(String{}, String{Valid: true}), want: false},
+			{name: "equal", got: Equal(Int64{Int64: 1, Valid: true}, Null[int64]{V: 1, Valid: true}), want: true},
+			{name: "different", got: Equal(Int64{Int64: 1, Valid: true}, Int64{Int64: 2, Valid: true}), want: false},
+			{name: "times in zones", got: Equal(Time{Time: stamp, Valid: true}, Time{Time: stamp.In(time.FixedZone("", 3600)), Valid: true}), want: true},
+			{name: "date and time", got: Equal(Date{Year: 2017, Month: time.November, Day: 24, Valid: true}, MakeTime(stamp.Truncate(24*time.Hour))), want: true},
+			{name: "decimals", got: Equal(a, b), want: true},
+			{name: "uuids", got: Equal(UUID{Valid: true}, UUID{UUID: [16]byte{1}, Valid: true}), want: false},
+		}
+		for _, tt := range tests {
+			if tt.got != tt.want {
+				t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+			}
+		}
+	})
+}
+
+func TestCombinators(t *testing.T) {
+	first, last := Null[string]{V: "Ada", Valid: true}, From(String{String: "Lovelace", Valid: true})
+	join := func(a, b string) string { return a + " " + b }
+
+	t.Run("Zip2", func(t *testing.T) {
+		if got := Zip2(first, last, join); got != (Null[string]{V: "Ada Lovelace", Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		if got := Zip2(first, Null[string]{}, join); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+		if got := Zip2(From(String{}), last, join); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+	})
+
+	t.Run("Zip3", func(t *testing.T) {
+		age := Null[int]{V: 36, Valid: true}
+		describe := func(a, b string, n int) string { return fmt.Sprintf("%s %s, %d", a, b, n) }
+		if got := Zip3(first, last, age, describe); got != (Null[string]{V: "Ada Lovelace, 36", Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		for _, tt := range [][3]bool{{false, true, true}, {true, false, true}, {true, true, false}} {
+			a, b, c := first, last, age
+			a.Valid, b.Valid, c.Valid = tt[0], tt[1], tt[2]
+			if got := Zip3(a, b, c, describe); got.Valid {
+				t.Fatalf("%v: expected null, got %+v", tt, got)
+			}
+		}
+	})
+
+	t.Run("Bind", func(t *testing.T) {
+		half := func(n int) Null[int] { return Null[int]{V: n / 2, Valid: n%2 == 0} }
+		if got := Bind(Null[int]{V: 4, Valid: true}, half); got != (Null[int]{V: 2, Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		if got := Bind(Null[int]{V: 3, Valid: true}, half); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+		if got := Bind(Null[int]{}, half); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		positive := func(n int64) bool { return n > 0 }
+		if got := Filter(Null[int64]{V: 1, Valid: true}, positive); got != (Null[int64]{V: 1, Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		if got := Filter(Null[int64]{V: -1, Valid: true}, positive); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+		if got := Filter(From(Int64{Int64: 1}), func(int64) bool { return true }); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+	})
+
+	t.Run("Coalesce", func(t *testing.T) {
+		if got := Coalesce(Null[int]{V: 1}, Null[int]{V: 2, Valid: true}, Null[int]{V: 3, Valid: true}); got != (Null[int]{V: 2, Valid: true}) {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+		if got := Coalesce(Null[int]{V: 1}, Null[int]{}); got != (Null[int]{}) {
+			t.Fatalf("expected null, got %+v", got)
+		}
+		if got := Coalesce[int](); got.Valid {
+			t.Fatalf("expected null, got %+v", got)
+		}
+	})
+
+	t.Run("FirstValid", func(t *testing.T) {
+		if v, ok := FirstValid(Null[string]{}, Null[string]{V: "b", Valid: true}, last); !ok || v != "b" {
+			t.Fatalf("FirstValid() = %q, %v", v, ok)
+		}
+		if v, ok := FirstValid(From(String{String: "stale"}), From(Optional[string]{})); ok || v != "" {
+			t.Fatalf("FirstValid() = %q, %v", v, ok)
+		}
+	})
+}
+
+func TestBool_Logic(t *testing.T) {
+	// Truth tables are indexed by false, true and null, in that order.
+	values := [3]Bool{{Bool: false, Valid: true}, {Bool: true, Valid: true}, {Bool: true}}
+	var (
+		F = Bool{Bool: false, Valid: true}
+		T = Bool{Bool: true, Valid: true}
+		U = Bool{}
+	)
+	operators := []struct {
+		name  string
+		op    func(a, b Bool) Bool
+		table [3][3]Bool
+	}{
+		{name: "And", op: Bool.And, table: [3][3]Bool{
+			{F, F, F},
+			{F, T, U},
+			{F, U, U},
+		}},
+		{name: "Or", op: Bool.Or, table: [3][3]Bool{
+			{F, T, U},
+			{T, T, T},
+			{U, T, U},
+		}},
+		{name: "Xor", op: Bool.Xor, table: [3][3]Bool{
+			{F, T, U},
+			{T, F, U},
+			{U, U, U},
+		}},
+		{name: "Implies", op: Bool.Implies, table: [3][3]Bool{
+			{T, T, T},
+			{F, T, U},
+			{U, T, U},
+		}},
+	}
+	for _, tt := range operators {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, a := range values {
+				for j, b := range values {
+					if got := tt.op(a, b); got != tt.table[i][j] {
+						t.Errorf("%+v %s %+v = %+v, want %+v", a, tt.name, b, got, tt.table[i][j])
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("Not", func(t *testing.T) {
+		for i, want := range [3]Bool{T, F, U} {
+			if got := values[i].Not(); got != want {
+				t.Errorf("Not %+v = %+v, want %+v", values[i], got, want)
+			}
+		}
+	})
+
+	t.Run("predicates", func(t *testing.T) {
+		want := [3][3]bool{
+			// IsTrue, IsFalse, IsUnknown
+			{false, true, false},
+			{true, false, false},
+			{false, false, true},
+		}
+		for i, v := range values {
+			if got := [3]bool{v.IsTrue(), v.IsFalse(), v.IsUnknown()}; got != want[i] {
+				t.Errorf("%+v: got %v, want %v", v, got, want[i])
+			}
+		}
+	})
+}
+
+func TestArithmetic(t *testing.T) {
+	i := func(v int64) Int64 { return Int64{Int64: v, Valid: true} }
+	f := func(v float64) Float64 { return Float64{Float64: v, Valid: true} }
+
+	t.Run("Int64", func(t *testing.T) {
+		tests := []struct {
+			name string
+			op   func(Int64, Int64) (Int64, error)
+			a, b Int64
+			want Int64
+			err  error
+		}{
+			{name: "add", op: Int64.Add, a: i(2), b: i(3), want: i(5)},
+			{name: "sub", op: Int64.Sub, a: i(2), b: i(3), want: i(-1)},
+			{name: "mul", op: Int64.Mul, a: i(2), b: i(-3), want: i(-6)},
+			{name: "div", op: Int64.Div, a: i(-7), b: i(2), want: i(-3)},
+			{name: "add null", op: Int64.Add, a: i(2), b: Int64{Int64: 3}, want: Int64{}},
+			{name: "sub null", op: Int64.Sub, a: Int64{}, b: i(3), want: Int64{}},
+			{name: "mul null", op: Int64.Mul, a: i(2), b: Int64{}, want: Int64{}},
+			{name: "div null", op: Int64.Div, a: i(7), b: Int64{}, want: Int64{}},
+			{name: "add max", op: Int64.Add, a: i(math.MaxInt64 - 1), b: i(1), want: i(math.MaxInt64)},
+			{name: "add overflow", op: Int64.Add, a: i(math.MaxInt64), b: i(1), err: ErrOverflow},
+			{name: "add underflow", op: Int64.Add, a: i(math.MinInt64), b: i(-1), err: ErrOverflow},
+			{name: "sub min", op: Int64.Sub, a: i(-1), b: i(math.MaxInt64), want: i(math.MinInt64)},
+			{name: "sub overflow", op: Int64.Sub, a: i(0), b: i(math.MinInt64), err: ErrOverflow},
+			{name: "sub underflow", op: Int64.Sub, a: i(math.MinInt64), b: i(1), err: ErrOverflow},
+			{name: "mul min", op: Int64.Mul, a: i(math.MinInt64 / 2), b: i(2), want: i(math.MinInt64)},
+			{name: "mul overflow", op: Int64.Mul, a: i(math.MaxInt64/2 + 1), b: i(2), err: ErrOverflow},
+			{name: "mul negate min", op: Int64.Mul, a: i(-1), b: i(math.MinInt64), err: ErrOverflow},
+			{name: "mul min negate", op: Int64.Mul, a: i(math.MinInt64), b: i(-1), err: ErrOverflow},
+			{name: "div overflow", op: Int64.Div, a: i(math.MinInt64), b: i(-1), err: ErrOverflow},
+		}
+		for _, tt := range tests {
+			if got, err := tt.op(tt.a, tt.b); got != tt.want || err != tt.err {
+				t.Errorf("%s: got %+v, %v, want %+v, %v", tt.name, got, err, tt.want, tt.err)
+			}
+		}
+
+		for _, tt := range []struct {
+			name      string
+			got, want Int64
+		}{
+			{name: "min", got: i(2).Min(i(-3)), want: i(-3)},
+			{name: "max", got: i(2).Max(i(-3)), want: i(2)},
+			{name: "min null", got: i(2).Min(Int64{}), want: Int64{}},
+			{name: "max null", got: Int64{}.Max(i(2)), want: Int64{}},
+		} {
+			if tt.got != tt.want {
+				t.Errorf("%s: got %+v, want %+v", tt.name, tt.got, tt.want)
+			}
+		}
+
+		for _, tt := range []struct {
+			a, b Int64
+			cmp  int
+			ok   bool
+		}{
+			{a: i(1), b: i(2), cmp: -1, ok: true},
+			{a: i(2), b: i(2), cmp: 0, ok: true},
+			{a: i(3), b: i(2), cmp: 1, ok: true},
+			{a: i(3), b: Int64{}, cmp: 0, ok: false},
+		} {
+			if cmp, ok := tt.a.Cmp(tt.b); cmp != tt.cmp || ok != tt.ok {
+				t.Errorf("%+v.Cmp(%+v) = %d, %v", tt.a, tt.b, cmp, ok)
+			}
+		}
+	})
+
+	t.Run("Float64", func(t *testing.T) {
+		tests := []struct {
+			name string
+			op   func(Float64, Float64) (Float64, error)
+			a, b Float64
+			want Float64
+			err  error
+		}{
+			{name: "add", op: Float64.Add, a: f(0.5), b: f(1), want: f(1.5)},
+			{name: "sub", op: Float64.Sub, a: f(0.5), b: f(1), want: f(-0.5)},
+			{name: "mul", op: Float64.Mul, a: f(0.5), b: f(-3), want: f(-1.5)},
+			{name: "div", op: Float64.Div, a: f(3), b: f(2), want: f(1.5)},
+			{name: "add null", op: Float64.Add, a: f(2), b: Float64{Float64: 3}, want: Float64{}},
+			{name: "sub null", op: Float64.Sub, a: Float64{}, b: f(3), want: Float64{}},
+			{name: "mul null", op: Float64.Mul, a: f(2), b: Float64{}, want: Float64{}},
+			{name: "div null", op: Float64.Div, a: f(2), b: Float64{}, want: Float64{}},
+			{name: "add overflow", op: Float64.Add, a: f(math.MaxFloat64), b: f(math.MaxFloat64), err: ErrOverflow},
+			{name: "sub overflow", op: Float64.Sub, a: f(-math.MaxFloat64), b: f(math.MaxFloat64), err: ErrOverflow},
+			{name: "mul overflow", op: Float64.Mul, a: f(math.MaxFloat64), b: f(2), err: ErrOverflow},
+			{name: "div overflow", op: Float64.Div, a: f(math.MaxFloat64), b: f(0.5), err: ErrOverflow},
+			{name: "add infinity", op: Float64.Add, a: f(math.Inf(1)), b: f(1), want: f(math.Inf(1))},
+		}
+		for _, tt := range tests {
+			if got, err := tt.op(tt.a, tt.b); got != tt.want || err != tt.err {
+				t.Errorf("%s: got %+v, %v, want %+v, %v", tt.name, got, err, tt.want, tt.err)
+			}
+		}
+
+		for _, tt := range []struct {
+			name      string
+			got, want Float64
+		}{
+			{name: "min", got: f(0.5).Min(f(-3)), want: f(-3)},
+			{name: "max", got: f(0.5).Max(f(-3)), want: f(0.5)},
+			{name: "min null", got: f(2).Min(Float64{}), want: Float64{}},
+			{name: "max null", got: Float64{}.Max(f(2)), want: Float64{}},
+		} {
+			if tt.got != tt.want {
+				t.Errorf("%s: got %+v, want %+v", tt.name, tt.got, tt.want)
+			}
+		}
+
+		// Min and Max order NaN as Cmp and cmp.Compare do, rather than propagating it.
+		if got := f(math.NaN()).Max(f(1)); got != f(1) {
+			t.Fatalf("Max() = %+v for NaN", got)
+		}
+		if got := f(1).Min(f(math.NaN())); !math.IsNaN(got.Float64) || !got.Valid {
+			t.Fatalf("Min() = %+v for NaN", got)
+		}
+		if cmp, ok := f(math.NaN()).Cmp(f(1)); cmp != -1 || !ok {
+			t.Fatalf("Cmp() = %d, %v for NaN", cmp, ok)
+		}
+		if _, ok := f(1).Cmp(Float64{}); ok {
+			t.Fatalf("Cmp() should not be ok for null")
+		}
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+		if got, err := i(1).Div(i(0)); err != ErrDivisionByZero || got.Valid {
+			t.Fatalf("Int64.Div() = %+v, %v", got, err)
+		}
+		if got, err := f(1).Div(f(0)); err != ErrDivisionByZero || got.Valid {
+			t.Fatalf("Float64.Div() = %+v, %v", got, err)
+		}
+
+		// Dividing by NULLIF(b, 0) gives null rather than an error, as in MySQL.
+		if got, err := i(1).Div(i(0).NullIf(0)); err != nil || got.Valid {
+			t.Fatalf("Int64.Div(NullIf()) = %+v, %v", got, err)
+		}
+		if got, err := i(7).Div(i(2).NullIf(0)); err != nil || got != i(3) {
+			t.Fatalf("Int64.Div(NullIf()) = %+v, %v", got, err)
+		}
+		if _, err := i(math.MinInt64).Div(i(-1).NullIf(0)); err != ErrOverflow {
+			t.Fatalf("Int64.Div(NullIf()) error = %v, want ErrOverflow", err)
+		}
+		if got, err := f(1).Div(f(0).NullIf(0)); err != nil || got.Valid {
+			t.Fatalf("Float64.Div(NullIf()) = %+v, %v", got, err)
+		}
+		if got, err := f(3).Div(f(2).NullIf(0)); err != nil || got != f(1.5) {
+			t.Fatalf("Float64.Div(NullIf()) = %+v, %v", got, err)
+		}
+		if got := (Int64{}).NullIf(0); got.Valid {
+			t.Fatalf("Int64.NullIf() = %+v for null", got)
+		}
+	})
+}
+
+func TestAggregates(t *testing.T) {
+	ints := []Int64{{Int64: 1, Valid: true}, {Int64: 100}, {Int64: 2, Valid: true}, {}}
+	floats := []Float64{{Float64: 0.5, Valid: true}, {}, {Float64: 2, Valid: true}, {Float64: 3.5, Valid: true}}
+
+	if got, err := Sum(ints); err != nil || got != (Int64{Int64: 3, Valid: true}) {
+		t.Errorf("Sum(ints) = %+v, %v", got, err)
+	}
+	if got, err := Sum(floats); err != nil || got != (Float64{Float64: 6, Valid: true}) {
+		t.Errorf("Sum(floats) = %+v, %v", got, err)
+	}
+	if got := Avg(ints); got != (Float64{Float64: 1.5, Valid: true}) {
+		t.Errorf("Avg(ints) = %+v", got)
+	}
+	if got := Avg(floats); got != (Float64{Float64: 2, Valid: true}) {
+		t.Errorf("Avg(floats) = %+v", got)
+	}
+	if got := Min(ints); got != (Int64{Int64: 1, Valid: true}) {
+		t.Errorf("Min(ints) = %+v", got)
+	}
+	if got := Max(ints); got != (Int64{Int64: 2, Valid: true}) {
+		t.Errorf("Max(ints) = %+v", got)
+	}
+	if got := Min(floats); got != (Float64{Float64: 0.5, Valid: true}) {
+		t.Errorf("Min(floats) = %+v", got)
+	}
+	if got := Max(floats); got != (Float64{Float64: 3.5, Valid: true}) {
+		t.Errorf("Max(floats) = %+v", got)
+	}
+	if got := Count(ints); got != 2 {
+		t.Errorf("Count(ints) = %d", got)
+	}
+	if got := Count([]String{{String: "a", Valid: true}, {}}); got != 1 {
+		t.Errorf("Count(strings) = %d", got)
+	}
+
+	// Like SQL, aggregates of no valid value are null, but COUNT is 0.
+	nulls := []Int64{{}, {Int64: 1}}
+	if got, err := Sum(nulls); err != nil || got.Valid {
+		t.Errorf("Sum(nulls) = %+v, %v", got, err)
+	}
+	if got := Avg(nulls); got.Valid {
+		t.Errorf("Avg(nulls) = %+v", got)
+	}
+	if got := Min(nulls); got.Valid {
+		t.Errorf("Min(nulls) = %+v", got)
+	}
+	if got := Max([]Float64(nil)); got.Valid {
+		t.Errorf("Max(nil) = %+v", got)
+	}
+	if got, err := Sum([]Float64(nil)); err != nil || got.Valid {
+		t.Errorf("Sum(nil) = %+v, %v", got, err)
+	}
+	if got := Count(nulls); got != 0 {
+		t.Errorf("Count(nulls) = %d", got)
+	}
+
+	big := []Int64{{Int64: math.MaxInt64, Valid: true}, {Int64: math.MaxInt64, Valid: true}}
+	if got, err := Sum(big); err != ErrOverflow || got.Valid {
+		t.Errorf("Sum(big) = %+v, %v, want ErrOverflow", got, err)
+	}
+	huge := []Float64{{Float64: math.MaxFloat64, Valid: true}, {Float64: math.MaxFloat64, Valid: true}}
+	if got, err := Sum(huge); err != ErrOverflow || got.Valid {
+		t.Errorf("Sum(huge) = %+v, %v, want ErrOverflow", got, err)
+	}
+	if got := Avg(big); got != (Float64{Float64: math.MaxInt64, Valid: true}) {
+		t.Errorf("Avg(big) = %+v", got)
+	}
+	// 2^53 + 1 is not a float64, so summing through float64 would give 2^53.
+	exact := []Int64{{Int64: 1<<53 + 1, Valid: true}, {Int64: 1<<53 + 1, Valid: true}, {Int64: 1<<53 + 1, Valid: true}, {Int64: 1<<53 + 3, Valid: true}}
+	if got := Avg(exact); got != (Float64{Float64: 1<<53 + 2, Valid: true}) {
+		t.Errorf("Avg(exact) = %+v", got)
+	}
+}
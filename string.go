@@ -34,6 +34,27 @@ func (n *String) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// MarshalText implements encoding.TextMarshaler
+func (n String) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n String) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return append(b, n.String...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// As with every type in this package, empty text is decoded as null.
+func (n *String) UnmarshalText(b []byte) error {
+	n.String = string(b)
+	n.Valid = len(b) > 0
+	return nil
+}
+
 // Scan implements the Scanner interface from database/sql
 func (n *String) Scan(src any) error {
 	// Set initial state for subsequent scans.
@@ -0,0 +1,147 @@
+package nullable
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Every type in this package implements encoding.TextMarshaler and
+// encoding.TextUnmarshaler following the same rule: a null value is
+// encoded as empty text, and empty text is decoded as null.
+//
+// Null and Optional values of types with no text form of their own,
+// such as structs or slices, use their JSON encoding as text, like JSON.
+
+// textAppender and binaryAppender are encoding.TextAppender and
+// encoding.BinaryAppender, which require Go 1.24.
+type (
+	textAppender interface {
+		AppendText(b []byte) ([]byte, error)
+	}
+	binaryAppender interface {
+		AppendBinary(b []byte) ([]byte, error)
+	}
+)
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// isTextType reports whether T has a text form of its own: it implements
+// encoding.TextMarshaler, or is a string, boolean or number kind. Other
+// types fall back to JSON for text, and to their default form elsewhere.
+func isTextType[T any]() bool {
+	t := reflect.TypeFor[T]()
+	if t.Implements(textMarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// appendText appends the text encoding of v to b, delegating to v if it
+// implements encoding.TextAppender or encoding.TextMarshaler, falling
+// back to strconv for basic kinds and to JSON for any other type.
+func appendText[T any](b []byte, v T) ([]byte, error) {
+	// The predeclared types are handled before v is boxed for appendTextAny,
+	// which would allocate. appendBinaryValue does the same.
+	switch v := any(v).(type) {
+	case string:
+		return append(b, v...), nil
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(b, v, 10), nil
+	case uint32:
+		return strconv.AppendUint(b, uint64(v), 10), nil
+	case float64:
+		return strconv.AppendFloat(b, v, 'g', -1, 64), nil
+	}
+	return appendTextAny(b, v)
+}
+
+// appendTextAny is appendText for any other type.
+func appendTextAny(b []byte, v any) ([]byte, error) {
+	switch m := v.(type) {
+	case textAppender:
+		return m.AppendText(b)
+	case encoding.TextMarshaler:
+		data, err := m.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return append(b, data...), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return append(b, rv.String()...), nil
+	case reflect.Bool:
+		return strconv.AppendBool(b, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(b, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(b, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, data...), nil
+}
+
+// unmarshalText decodes b into the value ptr points to, delegating to it if
+// it implements encoding.TextUnmarshaler, falling back to strconv for basic
+// kinds and to JSON for any other type.
+func unmarshalText(ptr any, b []byte) error {
+	if u, ok := ptr.(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(b)
+	}
+
+	rv := reflect.ValueOf(ptr).Elem()
+	s := string(b)
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(v)
+	default:
+		return json.Unmarshal(b, ptr)
+	}
+	return nil
+}
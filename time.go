@@ -3,9 +3,7 @@ package nullable
 import (
 	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 	"reflect"
-	"strings"
 	"time"
 )
 
@@ -15,36 +13,25 @@ type Time struct {
 	Valid bool // Valid is true if Time is not NULL
 }
 
-// MarshalJSON for Time
+// MarshalJSON for Time.
+// Valid times are encoded using TimeLayout.
 func (n Time) MarshalJSON() ([]byte, error) {
-	var a *time.Time
-	if n.Valid {
-		a = &n.Time
+	if !n.Valid {
+		return []byte("null"), nil
 	}
-	return json.Marshal(a)
+	return formatTimeJSON(n.Time, TimeLayout)
 }
 
-// UnmarshalJSON for Time
+// UnmarshalJSON for Time.
+// Each of TimeLayouts is tried in turn until one succeeds.
 func (n *Time) UnmarshalJSON(b []byte) error {
-	s := string(b)
-	s = strings.Trim(s, `"`)
-
-	var (
-		zeroTime time.Time
-		tim      time.Time
-		err      error
-	)
-
-	if strings.EqualFold(s, "null") {
-		return nil
-	}
-
-	if tim, err = time.Parse(time.RFC3339, s); err != nil {
+	tim, null, err := parseTimeJSON(b, TimeLayouts)
+	if err != nil {
 		n.Valid = false
 		return err
 	}
 
-	if tim == zeroTime {
+	if null || tim == emptyTime {
 		return nil
 	}
 
@@ -53,6 +40,36 @@ func (n *Time) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalText implements encoding.TextMarshaler.
+// Valid times are encoded using TimeLayout.
+func (n Time) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Time) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return appendTime(b, n.Time, TimeLayout), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Each of TimeLayouts is tried in turn until one succeeds.
+func (n *Time) UnmarshalText(b []byte) error {
+	n.Valid = false
+	if len(b) == 0 {
+		return nil
+	}
+	tim, err := parseTimeText(string(b), TimeLayouts)
+	if err != nil {
+		return err
+	}
+	n.Time = tim
+	n.Valid = tim != emptyTime
+	return nil
+}
+
 // Scan implements the Scanner interface from database/sql
 func (n *Time) Scan(src any) error {
 	// Set initial state for subsequent scans.
@@ -0,0 +1,232 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pseudo layouts which represent a time as a JSON number
+// of seconds or milliseconds since the Unix epoch.
+const (
+	LayoutUnix      = "unix"
+	LayoutUnixMilli = "unixmilli"
+)
+
+// TimeLayouts lists the layouts tried, in order, when decoding a Time from JSON.
+// Numeric values are only accepted if LayoutUnix or LayoutUnixMilli is listed.
+var TimeLayouts = []string{time.RFC3339}
+
+// TimeLayout is the layout used when encoding a Time to JSON.
+var TimeLayout = time.RFC3339Nano
+
+// TimeLocation is the location used for layouts which carry no zone information.
+var TimeLocation = time.UTC
+
+// unixMilliThreshold is the magnitude above which a number is taken
+// to be milliseconds when both Unix pseudo layouts are accepted.
+// In seconds it lies in the year 5138, in milliseconds in 1973.
+const unixMilliThreshold = 1e11
+
+// TimeFormat defines how a FormattedTime is decoded from and encoded to JSON.
+// Implementations are used through their zero value.
+type TimeFormat interface {
+	// Layouts returns the layouts tried, in order, when decoding.
+	Layouts() []string
+	// Layout returns the layout used when encoding.
+	Layout() string
+}
+
+// DefaultTimeFormat uses the package level TimeLayouts and TimeLayout.
+type DefaultTimeFormat struct{}
+
+// Layouts returns TimeLayouts
+func (DefaultTimeFormat) Layouts() []string { return TimeLayouts }
+
+// Layout returns TimeLayout
+func (DefaultTimeFormat) Layout() string { return TimeLayout }
+
+// FormattedTime defines a nullable time whose JSON layouts are chosen by F.
+// It behaves exactly like Time otherwise.
+//
+//	type Partner struct{}
+//
+//	func (Partner) Layouts() []string { return []string{time.DateTime, nullable.LayoutUnixMilli} }
+//	func (Partner) Layout() string    { return time.DateTime }
+//
+//	type Event struct {
+//		At nullable.FormattedTime[Partner] `json:"at"`
+//	}
+type FormattedTime[F TimeFormat] struct {
+	Time  time.Time
+	Valid bool // Valid is true if Time is not NULL
+}
+
+// MarshalJSON for FormattedTime
+func (n FormattedTime[F]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	var f F
+	return formatTimeJSON(n.Time, f.Layout())
+}
+
+// UnmarshalJSON for FormattedTime
+func (n *FormattedTime[F]) UnmarshalJSON(b []byte) error {
+	var f F
+	tim, null, err := parseTimeJSON(b, f.Layouts())
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	if null || tim == emptyTime {
+		return nil
+	}
+
+	n.Time = tim
+	n.Valid = true
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n FormattedTime[F]) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n FormattedTime[F]) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	var f F
+	return appendTime(b, n.Time, f.Layout()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *FormattedTime[F]) UnmarshalText(b []byte) error {
+	n.Valid = false
+	if len(b) == 0 {
+		return nil
+	}
+	var f F
+	tim, err := parseTimeText(string(b), f.Layouts())
+	if err != nil {
+		return err
+	}
+	n.Time = tim
+	n.Valid = tim != emptyTime
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql
+func (n *FormattedTime[F]) Scan(src any) error {
+	var t Time
+	err := t.Scan(src)
+	n.Time, n.Valid = t.Time, t.Valid
+	return err
+}
+
+// Value returns the database/sql driver value for FormattedTime
+func (n FormattedTime[F]) Value() (driver.Value, error) {
+	return Time{Time: n.Time, Valid: n.Valid}.Value()
+}
+
+// appendTime appends t, formatted using layout, to b.
+func appendTime(b []byte, t time.Time, layout string) []byte {
+	switch layout {
+	case LayoutUnix:
+		return strconv.AppendInt(b, t.Unix(), 10)
+	case LayoutUnixMilli:
+		return strconv.AppendInt(b, t.UnixMilli(), 10)
+	}
+	return t.AppendFormat(b, layout)
+}
+
+// formatTimeJSON encodes t as a JSON value using layout.
+func formatTimeJSON(t time.Time, layout string) ([]byte, error) {
+	if layout == LayoutUnix || layout == LayoutUnixMilli {
+		return appendTime(nil, t, layout), nil
+	}
+	return json.Marshal(t.Format(layout))
+}
+
+// parseTimeJSON decodes a JSON value into a time, trying each layout in turn.
+// It reports whether b was the null literal.
+func parseTimeJSON(b []byte, layouts []string) (time.Time, bool, error) {
+	if bytes.EqualFold(b, nullLiteral) {
+		return time.Time{}, true, nil
+	}
+	if isNumber(b) {
+		tim, err := parseUnix(string(b), layouts)
+		return tim, false, err
+	}
+
+	s := string(bytes.Trim(b, `"`))
+	if strings.EqualFold(s, "null") {
+		return time.Time{}, true, nil
+	}
+	tim, err := parseTimeLayouts(s, layouts)
+	return tim, false, err
+}
+
+// parseTimeText decodes text into a time. Integers are taken
+// as Unix times only if one of the Unix pseudo layouts is listed.
+func parseTimeText(s string, layouts []string) (time.Time, error) {
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		for _, layout := range layouts {
+			if layout == LayoutUnix || layout == LayoutUnixMilli {
+				return parseUnix(s, layouts)
+			}
+		}
+	}
+	return parseTimeLayouts(s, layouts)
+}
+
+// parseTimeLayouts parses s using each of the non-Unix layouts in turn.
+func parseTimeLayouts(s string, layouts []string) (time.Time, error) {
+	var err error
+	for _, layout := range layouts {
+		if layout == LayoutUnix || layout == LayoutUnixMilli {
+			continue
+		}
+		var tim time.Time
+		if tim, err = time.ParseInLocation(layout, s, TimeLocation); err == nil {
+			return tim, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("nullable: no layout to parse time %q", s)
+	}
+	return time.Time{}, err
+}
+
+func isNumber(b []byte) bool {
+	return len(b) > 0 && (b[0] == '-' || b[0] >= '0' && b[0] <= '9')
+}
+
+// parseUnix decodes a number of seconds or milliseconds since the Unix epoch,
+// depending on which of the Unix pseudo layouts are present in layouts.
+func parseUnix(s string, layouts []string) (time.Time, error) {
+	var seconds, millis bool
+	for _, layout := range layouts {
+		seconds = seconds || layout == LayoutUnix
+		millis = millis || layout == LayoutUnixMilli
+	}
+	if !seconds && !millis {
+		return time.Time{}, fmt.Errorf("nullable: cannot parse number %s as a time", s)
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if millis && (!seconds || math.Abs(float64(i)) >= unixMilliThreshold) {
+		return time.UnixMilli(i).In(TimeLocation), nil
+	}
+	return time.Unix(i, 0).In(TimeLocation), nil
+}
@@ -0,0 +1,148 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Layouts used to represent a TimeOfDay.
+const (
+	timeOfDayLayout      = "15:04:05.000"
+	timeOfDayValueLayout = "15:04:05.999999999"
+)
+
+// timeOfDayLayouts lists the layouts accepted when decoding a TimeOfDay.
+var timeOfDayLayouts = []string{timeOfDayValueLayout, "15:04"}
+
+// TimeOfDay defines a nullable wall clock time, without date or location,
+// suitable for SQL TIME columns.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+	Valid      bool // Valid is true if TimeOfDay is not NULL
+}
+
+// MarshalJSON for TimeOfDay
+func (n TimeOfDay) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.format(timeOfDayLayout))
+}
+
+// UnmarshalJSON for TimeOfDay
+func (n *TimeOfDay) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		n.Valid = false
+		return err
+	}
+	return n.scanString(s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n TimeOfDay) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n TimeOfDay) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return n.appendFormat(b, timeOfDayValueLayout), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *TimeOfDay) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	return n.scanString(string(b))
+}
+
+// Scan implements the Scanner interface from database/sql.
+// It accepts "15:04:05" style strings and byte slices, the clock of
+// time.Time values, and int64 values as a number of seconds since midnight.
+func (n *TimeOfDay) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		n.set(src)
+		return nil
+	case int64:
+		if src < 0 || src >= 24*60*60 {
+			return fmt.Errorf("nullable: %d seconds is out of range for TimeOfDay", src)
+		}
+		n.set(time.Unix(src, 0).UTC())
+		return nil
+	case string:
+		return n.scanString(src)
+	case []byte:
+		return n.scanString(string(src))
+	default:
+		return fmt.Errorf("nullable: cannot scan type %T into TimeOfDay", src)
+	}
+}
+
+// Value returns the database/sql driver value for TimeOfDay
+func (n TimeOfDay) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.format(timeOfDayValueLayout), nil
+}
+
+// On returns the Time at n on the given date
+func (n TimeOfDay) On(d Date, loc *time.Location) Time {
+	if !n.Valid || !d.Valid {
+		return Time{Valid: false}
+	}
+	return Time{Time: time.Date(d.Year, d.Month, d.Day, n.Hour, n.Minute, n.Second, n.Nanosecond, loc), Valid: true}
+}
+
+func (n *TimeOfDay) set(t time.Time) {
+	n.Hour, n.Minute, n.Second = t.Clock()
+	n.Nanosecond = t.Nanosecond()
+	n.Valid = true
+}
+
+func (n *TimeOfDay) scanString(s string) error {
+	var err error
+	for _, layout := range timeOfDayLayouts {
+		var tim time.Time
+		if tim, err = time.Parse(layout, s); err == nil {
+			n.set(tim)
+			return nil
+		}
+	}
+	n.Valid = false
+	return err
+}
+
+func (n TimeOfDay) sinceMidnight() time.Duration {
+	return time.Duration(n.Hour)*time.Hour + time.Duration(n.Minute)*time.Minute +
+		time.Duration(n.Second)*time.Second + time.Duration(n.Nanosecond)
+}
+
+func (n TimeOfDay) format(layout string) string {
+	return string(n.appendFormat(nil, layout))
+}
+
+func (n TimeOfDay) appendFormat(b []byte, layout string) []byte {
+	return time.Date(0, 1, 1, n.Hour, n.Minute, n.Second, n.Nanosecond, time.UTC).AppendFormat(b, layout)
+}
@@ -0,0 +1,91 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Uint16 defines a nullable uint16
+type Uint16 struct {
+	Uint16 uint16
+	Valid  bool // Valid is true if Uint16 is not NULL
+}
+
+// MarshalJSON for Uint16
+func (n Uint16) MarshalJSON() ([]byte, error) {
+	var a *uint16
+	if n.Valid {
+		a = &n.Uint16
+	}
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON for Uint16.
+// Values which do not fit in a uint16 are rejected.
+func (n *Uint16) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Uint16)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Uint16) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Uint16) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendUint(b, uint64(n.Uint16), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Uint16) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseUint(string(b), 10, 16)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Uint16 = uint16(v)
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Values which do not fit in a uint16 are rejected.
+func (n *Uint16) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var a sql.Null[uint16]
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	n.Uint16 = a.V
+	if reflect.TypeOf(src) != nil {
+		n.Valid = true
+	}
+	return nil
+}
+
+// Value returns the database/sql driver value for Uint16
+func (n Uint16) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Uint16), nil
+}
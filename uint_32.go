@@ -0,0 +1,91 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Uint32 defines a nullable uint32
+type Uint32 struct {
+	Uint32 uint32
+	Valid  bool // Valid is true if Uint32 is not NULL
+}
+
+// MarshalJSON for Uint32
+func (n Uint32) MarshalJSON() ([]byte, error) {
+	var a *uint32
+	if n.Valid {
+		a = &n.Uint32
+	}
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON for Uint32.
+// Values which do not fit in a uint32 are rejected.
+func (n *Uint32) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Uint32)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Uint32) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Uint32) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendUint(b, uint64(n.Uint32), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Uint32) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseUint(string(b), 10, 32)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Uint32 = uint32(v)
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Values which do not fit in a uint32 are rejected.
+func (n *Uint32) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var a sql.Null[uint32]
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	n.Uint32 = a.V
+	if reflect.TypeOf(src) != nil {
+		n.Valid = true
+	}
+	return nil
+}
+
+// Value returns the database/sql driver value for Uint32
+func (n Uint32) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Uint32), nil
+}
@@ -0,0 +1,96 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Uint64 defines a nullable uint64
+type Uint64 struct {
+	Uint64 uint64
+	Valid  bool // Valid is true if Uint64 is not NULL
+}
+
+// MarshalJSON for Uint64
+func (n Uint64) MarshalJSON() ([]byte, error) {
+	var a *uint64
+	if n.Valid {
+		a = &n.Uint64
+	}
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON for Uint64.
+// Values which do not fit in a uint64 are rejected.
+func (n *Uint64) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Uint64)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Uint64) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Uint64) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendUint(b, n.Uint64, 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Uint64) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Uint64 = v
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Values which do not fit in a uint64 are rejected.
+func (n *Uint64) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var a sql.Null[uint64]
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	n.Uint64 = a.V
+	if reflect.TypeOf(src) != nil {
+		n.Valid = true
+	}
+	return nil
+}
+
+// Value returns the database/sql driver value for Uint64
+func (n Uint64) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if n.Uint64 > math.MaxInt64 {
+		return nil, fmt.Errorf("nullable: Uint64 value %d overflows int64", n.Uint64)
+	}
+	return int64(n.Uint64), nil
+}
@@ -0,0 +1,94 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Uint8 defines a nullable uint8
+type Uint8 struct {
+	Uint8 uint8
+	Valid bool // Valid is true if Uint8 is not NULL
+}
+
+// Byte is an alias for Uint8
+type Byte = Uint8
+
+// MarshalJSON for Uint8
+func (n Uint8) MarshalJSON() ([]byte, error) {
+	var a *uint8
+	if n.Valid {
+		a = &n.Uint8
+	}
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON for Uint8.
+// Values which do not fit in a uint8 are rejected.
+func (n *Uint8) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	err := json.Unmarshal(b, &n.Uint8)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (n Uint8) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n Uint8) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return strconv.AppendUint(b, uint64(n.Uint8), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (n *Uint8) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.Valid = false
+		return nil
+	}
+	v, err := strconv.ParseUint(string(b), 10, 8)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Uint8 = uint8(v)
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// Values which do not fit in a uint8 are rejected.
+func (n *Uint8) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	var a sql.Null[uint8]
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	n.Uint8 = a.V
+	if reflect.TypeOf(src) != nil {
+		n.Valid = true
+	}
+	return nil
+}
+
+// Value returns the database/sql driver value for Uint8
+func (n Uint8) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Uint8), nil
+}
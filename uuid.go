@@ -0,0 +1,184 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// UUID defines a nullable RFC 4122 UUID
+type UUID struct {
+	UUID  [16]byte
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// ParseUUID parses a UUID in its canonical, braced, URN or unhyphenated form:
+//
+//	6ba7b810-9dad-11d1-80b4-00c04fd430c8
+//	{6ba7b810-9dad-11d1-80b4-00c04fd430c8}
+//	urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8
+//	6ba7b8109dad11d180b400c04fd430c8
+func ParseUUID(s string) (UUID, error) {
+	orig := s
+	switch {
+	case len(s) == 38 && s[0] == '{' && s[37] == '}':
+		s = s[1:37]
+	case len(s) == 45 && strings.EqualFold(s[:9], "urn:uuid:"):
+		s = s[9:]
+	}
+
+	var u UUID
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return UUID{}, fmt.Errorf("nullable: invalid UUID %q", orig)
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+	default:
+		return UUID{}, fmt.Errorf("nullable: invalid UUID %q", orig)
+	}
+	if _, err := hex.Decode(u.UUID[:], []byte(s)); err != nil {
+		return UUID{}, fmt.Errorf("nullable: invalid UUID %q", orig)
+	}
+	u.Valid = true
+	return u, nil
+}
+
+// String returns the canonical form of n, or an empty string if n is null.
+func (n UUID) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return string(n.appendCanonical(nil))
+}
+
+// MarshalJSON for UUID
+func (n UUID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	b := append(make([]byte, 0, 38), '"')
+	b = n.appendCanonical(b)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON for UUID
+func (n *UUID) UnmarshalJSON(b []byte) error {
+	if bytes.EqualFold(b, nullLiteral) {
+		n.Valid = false
+		return nil
+	}
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		n.Valid = false
+		return fmt.Errorf("nullable: invalid UUID %s", b)
+	}
+	return n.UnmarshalText(b[1 : len(b)-1])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// A null UUID is encoded as empty text.
+func (n UUID) MarshalText() ([]byte, error) {
+	return n.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, like MarshalText
+func (n UUID) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	return n.appendCanonical(b), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Empty text is decoded as a null UUID.
+func (n *UUID) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*n = UUID{}
+		return nil
+	}
+	u, err := ParseUUID(string(b))
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	*n = u
+	return nil
+}
+
+// Scan implements the Scanner interface from database/sql.
+// It accepts any textual form understood by ParseUUID,
+// as well as 16 byte slices holding the raw binary form.
+func (n *UUID) Scan(src any) error {
+	// Set initial state for subsequent scans.
+	n.Valid = false
+
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return n.scanText(src)
+	case []byte:
+		if len(src) == 16 {
+			copy(n.UUID[:], src)
+			n.Valid = true
+			return nil
+		}
+		return n.scanText(string(src))
+	default:
+		return fmt.Errorf("nullable: cannot scan type %T into UUID", src)
+	}
+}
+
+// scanText parses s for Scan. Unlike UnmarshalText, it rejects empty
+// input, since only a nil source means NULL to database/sql.
+func (n *UUID) scanText(s string) error {
+	u, err := ParseUUID(s)
+	if err != nil {
+		return err
+	}
+	*n = u
+	return nil
+}
+
+// Value returns the database/sql driver value for UUID,
+// as the canonical 36 character string form.
+func (n UUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.String(), nil
+}
+
+// Binary returns a driver.Valuer which writes n as its raw 16 bytes,
+// as used by MySQL BINARY(16) columns, which Scan reads back.
+func (n UUID) Binary() driver.Valuer {
+	return uuidBinary(n)
+}
+
+// uuidBinary is the driver.Valuer returned by UUID.Binary
+type uuidBinary UUID
+
+// Value returns the 16 bytes of n.UUID, or nil if n is null
+func (n uuidBinary) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID[:], nil
+}
+
+func (n UUID) appendCanonical(b []byte) []byte {
+	var buf [36]byte
+	hex.Encode(buf[0:8], n.UUID[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], n.UUID[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], n.UUID[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], n.UUID[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], n.UUID[10:])
+	return append(b, buf[:]...)
+}
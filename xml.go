@@ -0,0 +1,620 @@
+package nullable
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+)
+
+// xsiNamespace is the XML Schema instance namespace, which defines xsi:nil.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// marshalXML renders m as the text content of an element,
+// which is left out if null; see XMLNil.
+func marshalXML(e *xml.Encoder, start xml.StartElement, valid bool, m encoding.TextMarshaler) error {
+	if !valid {
+		return nil
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+// unmarshalXML decodes the text content of an element into u. Elements
+// marked with xsi:nil="true" are decoded as null, as is empty content.
+func unmarshalXML(d *xml.Decoder, start xml.StartElement, u encoding.TextUnmarshaler) error {
+	if xmlNil(start) {
+		if err := d.Skip(); err != nil {
+			return err
+		}
+		return u.UnmarshalText(nil)
+	}
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// xmlNil reports whether start is marked with xsi:nil="true".
+func xmlNil(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xsiNamespace || attr.Name.Space == "xsi") && attr.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// XMLNil wraps a nullable value so that, when null, it is rendered as an
+// element marked with xsi:nil="true" rather than left out of the document.
+// It behaves like the value it wraps otherwise, including as an attribute.
+//
+//	type Order struct {
+//		Coupon  nullable.String                 `xml:"coupon"`
+//		Shipped nullable.XMLNil[nullable.Time] `xml:"shipped"`
+//	}
+type XMLNil[N interface{ IsValid() bool }] struct {
+	V N
+}
+
+// MarshalXML implements xml.Marshaler
+func (n XMLNil[N]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if n.V.IsValid() {
+		return e.EncodeElement(n.V, start)
+	}
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+		xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+	)
+	return e.EncodeElement("", start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, like the UnmarshalXML method of N
+func (n *XMLNil[N]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return d.DecodeElement(&n.V, &start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr, like the MarshalXMLAttr method of N
+func (n XMLNil[N]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if m, ok := any(n.V).(xml.MarshalerAttr); ok {
+		return m.MarshalXMLAttr(name)
+	}
+	return xml.Attr{}, fmt.Errorf("nullable: %T cannot be rendered as an XML attribute", n.V)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, like the UnmarshalXMLAttr method of N
+func (n *XMLNil[N]) UnmarshalXMLAttr(attr xml.Attr) error {
+	if u, ok := any(&n.V).(xml.UnmarshalerAttr); ok {
+		return u.UnmarshalXMLAttr(attr)
+	}
+	return fmt.Errorf("nullable: %T cannot be decoded from an XML attribute", n.V)
+}
+
+// marshalXMLAttr renders m as an attribute, which is left out if null.
+func marshalXMLAttr(name xml.Name, valid bool, m encoding.TextMarshaler) (xml.Attr, error) {
+	if !valid {
+		return xml.Attr{}, nil
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// MarshalXML implements xml.Marshaler
+func (n String) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *String) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *String) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Int64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Int64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Int64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Int64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Int32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Int32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Int32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Int32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Int16) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Int16) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Int16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Int16) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Int8) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Int8) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Int8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Int8) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Uint64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Uint64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Uint64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Uint64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Uint32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Uint32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Uint32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Uint32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Uint16) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Uint16) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Uint16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Uint16) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Uint8) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Uint8) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Uint8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Uint8) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Float64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Float64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Float64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Float64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Float32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Float32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Float32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Float32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Bool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Time) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Time) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Time) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n FormattedTime[F]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *FormattedTime[F]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n FormattedTime[F]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *FormattedTime[F]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Date) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Date) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Date) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n TimeOfDay) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *TimeOfDay) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n TimeOfDay) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *TimeOfDay) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Duration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Duration) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n UUID) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *UUID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n UUID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *UUID) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Decimal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Decimal) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Decimal) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Decimal) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n Bytes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *Bytes) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Bytes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Bytes) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n BytesAs[E]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *BytesAs[E]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n BytesAs[E]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *BytesAs[E]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n RawJSON) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, len(n) > 0, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *RawJSON) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n RawJSON) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, len(n) > 0, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *RawJSON) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler.
+// Valid values of T with no text form, such as structs,
+// are encoded as nested elements as usual.
+func (n Null[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if n.Valid && !isTextType[T]() {
+		return e.EncodeElement(n.V, start)
+	}
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// Values of T with no text form are decoded from nested elements as usual.
+func (n *Null[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if !isTextType[T]() && !xmlNil(start) {
+		err := d.DecodeElement(&n.V, &start)
+		n.Valid = err == nil
+		return err
+	}
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n Null[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *Null[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler, like Null.MarshalXML
+func (o Optional[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return Null[T]{V: o.V, Valid: o.Valid}.MarshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, like Null.UnmarshalXML
+func (o *Optional[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[T]{V: o.V}
+	err := n.UnmarshalXML(d, start)
+	o.V, o.Valid, o.Present = n.V, n.Valid, true
+	return err
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (o Optional[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, o.Valid, o)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (o *Optional[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return o.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler
+func (n JSON[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, n.Valid, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (n *JSON[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, n)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (n JSON[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n.Valid, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr
+func (n *JSON[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
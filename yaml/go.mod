@@ -0,0 +1,10 @@
+module github.com/ladydascalie/nullable/yaml
+
+go 1.22
+
+require (
+	github.com/ladydascalie/nullable v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/ladydascalie/nullable => ../
@@ -0,0 +1,497 @@
+// Package yaml encodes and decodes values holding the types of package
+// nullable as YAML with gopkg.in/yaml.v3, so that null values are encoded as
+// YAML null and valid values as their native YAML value, rather than as maps.
+//
+// Marshal and Unmarshal stand in for those of gopkg.in/yaml.v3:
+//
+//	err := yaml.Unmarshal(b, &config)
+//
+// Decoding behaves as JSON does: a missing key leaves its field untouched,
+// while ~ and null reset it to null and mark an Optional as present.
+// gopkg.in/yaml.v3 never hands null to the unmarshaler of a struct, so the
+// types of package nullable cannot do this themselves: Decode walks the YAML
+// nodes alongside the value decoded into, and leaves everything else to
+// gopkg.in/yaml.v3. Types decoded by gopkg.in/yaml.v3 directly get the same
+// behaviour by implementing yaml.Unmarshaler and yaml.Marshaler through
+// Decode and Encode:
+//
+//	func (c *Config) UnmarshalYAML(n *yamlv3.Node) error {
+//		type plain Config
+//		return yaml.Decode(n, (*plain)(c))
+//	}
+//
+//	func (c Config) MarshalYAML() (any, error) {
+//		type plain Config
+//		return yaml.Encode(plain(c))
+//	}
+//
+// Values are mapped as follows:
+//
+//	String                           str
+//	Int64, Int32, Int16, Int8        int
+//	Uint64, Uint32, Uint16, Uint8    int
+//	Float64, Float32                 float
+//	Bool                             bool
+//	Null, Optional, JSON             the YAML value of V
+//	all other types                  str, using their text encoding
+package yaml
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ladydascalie/nullable"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var (
+	pkgPath             = reflect.TypeFor[nullable.String]().PkgPath()
+	validType           = reflect.TypeFor[interface{ IsValid() bool }]()
+	marshalerType       = reflect.TypeFor[yamlv3.Marshaler]()
+	unmarshalerType     = reflect.TypeFor[yamlv3.Unmarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// native holds the types encoded as the YAML scalar of their first field
+var native = map[reflect.Type]bool{
+	reflect.TypeFor[nullable.String]():  true,
+	reflect.TypeFor[nullable.Int64]():   true,
+	reflect.TypeFor[nullable.Int32]():   true,
+	reflect.TypeFor[nullable.Int16]():   true,
+	reflect.TypeFor[nullable.Int8]():    true,
+	reflect.TypeFor[nullable.Uint64]():  true,
+	reflect.TypeFor[nullable.Uint32]():  true,
+	reflect.TypeFor[nullable.Uint16]():  true,
+	reflect.TypeFor[nullable.Uint8]():   true,
+	reflect.TypeFor[nullable.Float64](): true,
+	reflect.TypeFor[nullable.Float32](): true,
+	reflect.TypeFor[nullable.Bool]():    true,
+}
+
+// Unmarshal decodes the YAML document in data into v, like yaml.Unmarshal
+func Unmarshal(data []byte, v any) error {
+	var n yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	if n.Kind == 0 {
+		return nil
+	}
+	return Decode(&n, v)
+}
+
+// Marshal returns the YAML encoding of v, like yaml.Marshal
+func Marshal(v any) ([]byte, error) {
+	n, err := Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return yamlv3.Marshal(n)
+}
+
+// Decode decodes n into v, which must be a non-nil pointer, like n.Decode
+func Decode(n *yamlv3.Node, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("nullable/yaml: cannot decode into %T", v)
+	}
+	return decode(n, rv.Elem())
+}
+
+// Encode returns the YAML node of v, like n.Encode
+func Encode(v any) (*yamlv3.Node, error) {
+	return encode(reflect.ValueOf(v))
+}
+
+func decode(n *yamlv3.Node, v reflect.Value) error {
+	switch n.Kind {
+	case yamlv3.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return decode(n.Content[0], v)
+	case yamlv3.AliasNode:
+		return decode(n.Alias, v)
+	}
+
+	t := v.Type()
+	switch {
+	case isNullable(t):
+		return decodeNullable(n, v)
+	case !holdsNullable(t):
+		return n.Decode(v.Addr().Interface())
+	case isNull(n):
+		// As with gopkg.in/yaml.v3, null leaves structs untouched.
+		if t.Kind() != reflect.Struct {
+			v.SetZero()
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return decode(n, v.Elem())
+	case reflect.Struct:
+		if n.Kind == yamlv3.MappingNode {
+			return decodeStruct(n, v)
+		}
+	case reflect.Slice:
+		if n.Kind == yamlv3.SequenceNode {
+			s := reflect.MakeSlice(t, len(n.Content), len(n.Content))
+			for i, e := range n.Content {
+				if err := decode(e, s.Index(i)); err != nil {
+					return err
+				}
+			}
+			v.Set(s)
+			return nil
+		}
+	case reflect.Map:
+		if n.Kind == yamlv3.MappingNode {
+			return decodeMap(n, v)
+		}
+	}
+	// Let gopkg.in/yaml.v3 report the mismatch.
+	return n.Decode(v.Addr().Interface())
+}
+
+func decodeStruct(n *yamlv3.Node, v reflect.Value) error {
+	fields := map[string][]int{}
+	for _, f := range structFields(v.Type()) {
+		fields[f.name] = f.index
+	}
+	content := pairs(n)
+	for i := 0; i < len(content); i += 2 {
+		index, ok := fields[content[i].Value]
+		if !ok {
+			continue
+		}
+		if err := decode(content[i+1], v.FieldByIndex(index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeMap(n *yamlv3.Node, v reflect.Value) error {
+	t := v.Type()
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	content := pairs(n)
+	for i := 0; i < len(content); i += 2 {
+		k := reflect.New(t.Key())
+		if err := content[i].Decode(k.Interface()); err != nil {
+			return err
+		}
+		e := reflect.New(t.Elem()).Elem()
+		if err := decode(content[i+1], e); err != nil {
+			return err
+		}
+		v.SetMapIndex(k.Elem(), e)
+	}
+	return nil
+}
+
+// decodeNullable decodes n into v, which holds one of the types of package nullable
+func decodeNullable(n *yamlv3.Node, v reflect.Value) error {
+	if v.Kind() == reflect.Struct {
+		if present := v.FieldByName("Present"); present.IsValid() {
+			defer present.SetBool(true)
+		}
+	}
+	if isNull(n) {
+		v.SetZero()
+		return nil
+	}
+
+	var err error
+	switch t := v.Type(); {
+	case native[t]:
+		err = n.Decode(v.Field(0).Addr().Interface())
+	case hasV(t):
+		err = decode(n, v.FieldByName("V"))
+	case n.Kind == yamlv3.ScalarNode:
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(n.Value)); err != nil {
+			return fmt.Errorf("nullable/yaml: line %d: %w", n.Line, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("nullable/yaml: line %d: cannot decode %s into %s", n.Line, n.ShortTag(), t)
+	}
+	if err != nil {
+		return err
+	}
+	v.FieldByName("Valid").SetBool(true)
+	return nil
+}
+
+func encode(v reflect.Value) (*yamlv3.Node, error) {
+	if !v.IsValid() {
+		return nullNode(), nil
+	}
+	t := v.Type()
+	switch {
+	case isNullable(t):
+		return encodeNullable(v)
+	case !holdsNullable(t):
+		return encodeValue(v.Interface())
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nullNode(), nil
+		}
+		return encode(v.Elem())
+	case reflect.Struct:
+		return encodeStruct(v)
+	case reflect.Slice:
+		s := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq"}
+		for i := 0; i < v.Len(); i++ {
+			e, err := encode(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			s.Content = append(s.Content, e)
+		}
+		return s, nil
+	case reflect.Map:
+		return encodeMap(v)
+	}
+	return encodeValue(v.Interface())
+}
+
+func encodeStruct(v reflect.Value) (*yamlv3.Node, error) {
+	m := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+	for _, f := range structFields(v.Type()) {
+		fv := v.FieldByIndex(f.index)
+		if f.omitEmpty && isEmpty(fv) {
+			continue
+		}
+		e, err := encode(fv)
+		if err != nil {
+			return nil, err
+		}
+		if f.flow {
+			e.Style |= yamlv3.FlowStyle
+		}
+		k := &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: f.name}
+		m.Content = append(m.Content, k, e)
+	}
+	return m, nil
+}
+
+func encodeMap(v reflect.Value) (*yamlv3.Node, error) {
+	type pair struct{ k, v *yamlv3.Node }
+	var ps []pair
+	iter := v.MapRange()
+	for iter.Next() {
+		k, err := encodeValue(iter.Key().Interface())
+		if err != nil {
+			return nil, err
+		}
+		e, err := encode(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, pair{k, e})
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i].k.Value < ps[j].k.Value })
+
+	m := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+	for _, p := range ps {
+		m.Content = append(m.Content, p.k, p.v)
+	}
+	return m, nil
+}
+
+// encodeNullable encodes v, which holds one of the types of package nullable
+func encodeNullable(v reflect.Value) (*yamlv3.Node, error) {
+	if !v.Interface().(interface{ IsValid() bool }).IsValid() {
+		return nullNode(), nil
+	}
+	switch t := v.Type(); {
+	case native[t]:
+		return encodeValue(v.Field(0).Interface())
+	case hasV(t):
+		return encode(v.FieldByName("V"))
+	}
+	text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return encodeValue(string(text))
+}
+
+func encodeValue(v any) (*yamlv3.Node, error) {
+	n := new(yamlv3.Node)
+	if err := n.Encode(v); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func nullNode() *yamlv3.Node {
+	return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+func isNull(n *yamlv3.Node) bool {
+	return n.Kind == yamlv3.ScalarNode && n.ShortTag() == "!!null"
+}
+
+// isNullable reports whether t is one of the types of package nullable
+func isNullable(t reflect.Type) bool {
+	return t.PkgPath() == pkgPath && t.Implements(validType)
+}
+
+// hasV reports whether t is Null, Optional or JSON, whose value is V
+func hasV(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := t.FieldByName("V")
+	return ok
+}
+
+// holding caches the results of holdsNullable
+var holding sync.Map
+
+// holdsNullable reports whether values of t may hold one of the types of
+// package nullable, and so must be walked rather than left to gopkg.in/yaml.v3.
+// Types with their own YAML or text encoding are left to it.
+func holdsNullable(t reflect.Type) bool {
+	if ok, found := holding.Load(t); found {
+		return ok.(bool)
+	}
+	ok := holds(t, map[reflect.Type]bool{})
+	holding.Store(t, ok)
+	return ok
+}
+
+func holds(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if isNullable(t) {
+		return true
+	}
+	if seen[t] || hasOwnEncoding(t) {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return holds(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if holds(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasOwnEncoding(t reflect.Type) bool {
+	p := reflect.PointerTo(t)
+	return t.Implements(marshalerType) || p.Implements(unmarshalerType) ||
+		t.Implements(textMarshalerType) || p.Implements(textUnmarshalerType)
+}
+
+// pairs returns the keys and values of mapping node n, preceded by those
+// merged into it with <<, so that its own keys take precedence
+func pairs(n *yamlv3.Node) []*yamlv3.Node {
+	var merged, own []*yamlv3.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if k.ShortTag() != "!!merge" {
+			own = append(own, k, v)
+			continue
+		}
+		switch v = deref(v); v.Kind {
+		case yamlv3.MappingNode:
+			merged = append(merged, pairs(v)...)
+		case yamlv3.SequenceNode:
+			// Earlier maps take precedence over later ones.
+			for j := len(v.Content) - 1; j >= 0; j-- {
+				merged = append(merged, pairs(deref(v.Content[j]))...)
+			}
+		}
+	}
+	return append(merged, own...)
+}
+
+func deref(n *yamlv3.Node) *yamlv3.Node {
+	if n.Kind == yamlv3.AliasNode {
+		return n.Alias
+	}
+	return n
+}
+
+// field is a struct field as gopkg.in/yaml.v3 sees it
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	flow      bool
+}
+
+// structFields returns the fields of struct type t, named after their
+// yaml tag or their lowercased name, with inline structs flattened
+func structFields(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("yaml")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		f := field{name: name, index: []int{i}}
+		inline := false
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "flow":
+				f.flow = true
+			case "inline":
+				inline = true
+			}
+		}
+		if inline && sf.Type.Kind() == reflect.Struct {
+			for _, g := range structFields(sf.Type) {
+				g.index = append([]int{i}, g.index...)
+				fields = append(fields, g)
+			}
+			continue
+		}
+		if f.name == "" {
+			f.name = strings.ToLower(sf.Name)
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// isEmpty reports whether v is left out by omitempty, as in gopkg.in/yaml.v3
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return true
+		}
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,290 @@
+package yaml
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ladydascalie/nullable"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type config struct {
+	Name     nullable.String           `yaml:"name"`
+	Port     nullable.Int64            `yaml:"port"`
+	Workers  nullable.Uint8            `yaml:"workers"`
+	Ratio    nullable.Float64          `yaml:"ratio"`
+	Debug    nullable.Bool             `yaml:"debug"`
+	Started  nullable.Time             `yaml:"started"`
+	Timeout  nullable.Duration         `yaml:"timeout"`
+	Budget   nullable.Decimal          `yaml:"budget"`
+	ID       nullable.UUID             `yaml:"id"`
+	Tags     nullable.Null[[]string]   `yaml:"tags"`
+	Override nullable.Optional[string] `yaml:"override"`
+}
+
+// UnmarshalYAML and MarshalYAML let config be decoded by gopkg.in/yaml.v3
+func (c *config) UnmarshalYAML(n *yamlv3.Node) error {
+	type plain config
+	return Decode(n, (*plain)(c))
+}
+
+func (c config) MarshalYAML() (any, error) {
+	type plain config
+	return Encode(plain(c))
+}
+
+// full returns a config with every field valid
+func full() config {
+	budget, _ := nullable.ParseDecimal("10.50")
+	id, _ := nullable.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	return config{
+		Name:     nullable.String{String: "api", Valid: true},
+		Port:     nullable.Int64{Int64: 8080, Valid: true},
+		Workers:  nullable.Uint8{Uint8: 4, Valid: true},
+		Ratio:    nullable.Float64{Float64: 0.5, Valid: true},
+		Debug:    nullable.Bool{Bool: false, Valid: true},
+		Started:  nullable.Time{Time: time.Date(2017, 11, 24, 10, 30, 0, 0, time.UTC), Valid: true},
+		Timeout:  nullable.Duration{Duration: 90 * time.Second, Valid: true},
+		Budget:   budget,
+		ID:       id,
+		Tags:     nullable.Null[[]string]{V: []string{"a", "b"}, Valid: true},
+		Override: nullable.Optional[string]{V: "x", Valid: true, Present: true},
+	}
+}
+
+func TestYAML(t *testing.T) {
+	source := `
+name: api
+port: 8080
+workers: 4
+ratio: 0.5
+debug: false
+started: 2017-11-24T10:30:00Z
+timeout: PT1M30S
+budget: 10.50
+id: 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+tags: [a, b]
+override: x
+`
+	t.Run("Unmarshal", func(t *testing.T) {
+		var got config
+		if err := yamlv3.Unmarshal([]byte(source), &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := full(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("\nexp: %+v\ngot: %+v", want, got)
+		}
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		want := full()
+		want.Ratio = nullable.Float64{}
+		b, err := yamlv3.Marshal(want)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `name: api
+port: 8080
+workers: 4
+ratio: null
+debug: false
+started: "2017-11-24T10:30:00Z"
+timeout: 1m30s
+budget: "10.50"
+id: 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+tags:
+    - a
+    - b
+override: x
+`
+		if string(b) != exp {
+			t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+		}
+
+		var back config
+		if err := yamlv3.Unmarshal(b, &back); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(back, want) {
+			t.Fatalf("\nexp: %+v\ngot: %+v", want, back)
+		}
+	})
+}
+
+func TestNulls(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   func(c *config)
+	}{
+		{
+			name:   "tilde",
+			source: "name: ~\nstarted: ~\nid: ~\ntags: ~\noverride: ~\n",
+			want: func(c *config) {
+				c.Name, c.Started, c.ID, c.Tags = nullable.String{}, nullable.Time{}, nullable.UUID{}, nullable.Null[[]string]{}
+				c.Override = nullable.Optional[string]{Present: true}
+			},
+		},
+		{
+			name:   "null",
+			source: "port: null\nbudget: null\ntimeout: null\noverride: null\n",
+			want: func(c *config) {
+				c.Port, c.Budget, c.Timeout = nullable.Int64{}, nullable.Decimal{}, nullable.Duration{}
+				c.Override = nullable.Optional[string]{Present: true}
+			},
+		},
+		{
+			name:   "empty value",
+			source: "debug:\n",
+			want:   func(c *config) { c.Debug = nullable.Bool{} },
+		},
+		{
+			name:   "missing keys",
+			source: "name: web\n",
+			want:   func(c *config) { c.Name.String = "web" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Decode into valid values, so that null is seen to reset them.
+			got := full()
+			if err := yamlv3.Unmarshal([]byte(tt.source), &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := full()
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("\nexp: %+v\ngot: %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestOptional(t *testing.T) {
+	type patch struct {
+		O nullable.Optional[int] `yaml:"o"`
+	}
+	tests := []struct {
+		source string
+		want   nullable.Optional[int]
+	}{
+		{source: "o: 1\n", want: nullable.Optional[int]{V: 1, Valid: true, Present: true}},
+		{source: "o: ~\n", want: nullable.Optional[int]{Present: true}},
+		{source: "o: null\n", want: nullable.Optional[int]{Present: true}},
+		{source: "p: 1\n", want: nullable.Optional[int]{}},
+	}
+	for _, tt := range tests {
+		var got patch
+		if err := Unmarshal([]byte(tt.source), &got); err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.source, err)
+		}
+		if got.O != tt.want {
+			t.Fatalf("%q:\nexp: %+v\ngot: %+v", tt.source, tt.want, got.O)
+		}
+	}
+}
+
+func TestNested(t *testing.T) {
+	type server struct {
+		Host nullable.String `yaml:"host"`
+		Port nullable.Int64  `yaml:"port,omitempty"`
+	}
+	type base struct {
+		Region nullable.String `yaml:"region"`
+	}
+	type deployment struct {
+		Base    base                      `yaml:",inline"`
+		Primary *server                   `yaml:"primary"`
+		Servers []server                  `yaml:"servers"`
+		Pools   map[string]server         `yaml:"pools"`
+		Backup  nullable.Null[server]     `yaml:"backup"`
+		Extra   map[string]nullable.Int64 `yaml:"extra"`
+	}
+
+	source := `
+defaults: &defaults
+  host: localhost
+  port: 80
+region: ~
+primary:
+  <<: *defaults
+  port: ~
+servers:
+  - host: a
+    port: 1
+  - host: ~
+pools:
+  x:
+    <<: *defaults
+backup:
+  host: b
+extra:
+  a: 1
+  b: ~
+`
+	got := deployment{
+		Base:    base{Region: nullable.String{String: "eu", Valid: true}},
+		Primary: &server{Port: nullable.Int64{Int64: 8080, Valid: true}},
+	}
+	if err := Unmarshal([]byte(source), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := deployment{
+		Primary: &server{Host: nullable.String{String: "localhost", Valid: true}},
+		Servers: []server{
+			{Host: nullable.String{String: "a", Valid: true}, Port: nullable.Int64{Int64: 1, Valid: true}},
+			{},
+		},
+		Pools: map[string]server{
+			"x": {Host: nullable.String{String: "localhost", Valid: true}, Port: nullable.Int64{Int64: 80, Valid: true}},
+		},
+		Backup: nullable.Null[server]{V: server{Host: nullable.String{String: "b", Valid: true}}, Valid: true},
+		Extra:  map[string]nullable.Int64{"a": {Int64: 1, Valid: true}, "b": {}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("\nexp: %+v\ngot: %+v", want, got)
+	}
+
+	b, err := Marshal(want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := `region: null
+primary:
+    host: localhost
+servers:
+    - host: a
+      port: 1
+    - host: null
+pools:
+    x:
+        host: localhost
+        port: 80
+backup:
+    host: b
+extra:
+    a: 1
+    b: null
+`
+	if string(b) != exp {
+		t.Fatalf("\nexp: %q\ngot: %q", exp, string(b))
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, source := range []string{
+		"workers: 256\n",
+		"timeout: forever\n",
+		"started: [2017]\n",
+		"tags: {a: b}\n",
+	} {
+		var got config
+		if err := yamlv3.Unmarshal([]byte(source), &got); err == nil {
+			t.Fatalf("%q: expected an error, got %+v", source, got)
+		}
+	}
+	if err := Decode(&yamlv3.Node{}, config{}); err == nil {
+		t.Fatal("expected an error decoding into a non-pointer")
+	}
+}